@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/berquerant/gbrowse/env"
@@ -12,25 +13,69 @@ import (
 type config struct {
 	DefaultBranch   string `json:"default_branch"`
 	RemoteOriginURL string `json:"remote_origin_url"`
-	HeadObjectName  string `json:"head_object_name"`
-	ShowPrefix      string `json:"show_prefix"`
-	RelativePath    string `json:"relative_path"`
-	DescribeTag     string `json:"describe_tag"`
-	ShowCurrent     string `json:"show_current"`
-	CommitHash      string `json:"commit_hash"`
+	// RemoteOriginPushURL is the push url of origin, default is RemoteOriginURL.
+	RemoteOriginPushURL string `json:"remote_origin_push_url"`
+	HeadObjectName      string `json:"head_object_name"`
+	ShowPrefix          string `json:"show_prefix"`
+	ShowToplevel        string `json:"show_toplevel"`
+	RelativePath        string `json:"relative_path"`
+	DescribeTag         string `json:"describe_tag"`
+	ShowCurrent         string `json:"show_current"`
+	CommitHash          string `json:"commit_hash"`
+	ConfigGetRegexp     string `json:"config_get_regexp"`
+	ConfigFileGetRegexp string `json:"config_file_get_regexp"`
+	SymbolicFullName    string `json:"symbolic_full_name"`
+	RevParseCommit      string `json:"rev_parse_commit"`
+	RevListBoundary     string `json:"rev_list_boundary"`
+	// ForEachRefContains is the remote-tracking refs containing the commit, empty if not pushed.
+	ForEachRefContains string `json:"for_each_ref_contains"`
+	Remotes            string `json:"remotes"`
+	BranchRemote       string `json:"branch_remote"`
+	Show               string `json:"show"`
+	// RemoteURLs is the urls of the remotes other than origin.
+	RemoteURLs map[string]string `json:"remote_urls"`
 }
 
 func (c *config) intoMappingTuples() mappingTupleList {
-	return []*mappingTuple{
+	remoteNames := make([]string, 0, len(c.RemoteURLs))
+	for name := range c.RemoteURLs {
+		remoteNames = append(remoteNames, name)
+	}
+	slices.Sort(remoteNames)
+	var remoteURLs mappingTupleList
+	for _, name := range remoteNames {
+		remoteURLs = append(remoteURLs,
+			newMappingTuple([]string{"remote", "get-url", "--push", name}, c.RemoteURLs[name]),
+			newMappingTuple([]string{"remote", "get-url", name}, c.RemoteURLs[name]),
+		)
+	}
+	originPushURL := c.RemoteOriginPushURL
+	if originPushURL == "" {
+		originPushURL = c.RemoteOriginURL
+	}
+
+	return append(remoteURLs, []*mappingTuple{
 		newMappingTuple([]string{"remote", "show", "origin"}, fmt.Sprintf("HEAD branch: %s", c.DefaultBranch)),
-		newMappingTuple([]string{"config", "--get", "remote.origin.url"}, c.RemoteOriginURL),
+		newMappingTuple([]string{"remote", "get-url", "--push", "origin"}, originPushURL),
+		newMappingTuple([]string{"remote", "get-url", "origin"}, c.RemoteOriginURL),
 		newMappingTuple([]string{"rev-parse", "--abbrev-ref", "@"}, c.HeadObjectName),
 		newMappingTuple([]string{"rev-parse", "--show-prefix"}, c.ShowPrefix),
+		newMappingTuple([]string{"rev-parse", "--show-toplevel"}, c.ShowToplevel),
 		newMappingTuple([]string{"ls-files", "--full-name"}, c.RelativePath),
 		newMappingTuple([]string{"describe", "--tags", "--abbrev=0"}, c.DescribeTag),
 		newMappingTuple([]string{"branch", "--show-current"}, c.ShowCurrent),
 		newMappingTuple([]string{"rev-parse", "@"}, c.CommitHash),
-	}
+		newMappingTuple([]string{"config", "--get-regexp"}, c.ConfigGetRegexp),
+		newMappingTuple([]string{"config", "--file"}, c.ConfigFileGetRegexp),
+		newMappingTuple([]string{"rev-parse", "--symbolic-full-name"}, c.SymbolicFullName),
+		newMappingTuple([]string{"rev-parse", "--verify"}, c.RevParseCommit),
+		newMappingTuple([]string{"rev-list", "--boundary"}, c.RevListBoundary),
+		newMappingTuple([]string{"for-each-ref", "--count=1", "--format=%(refname)", "--contains"}, c.ForEachRefContains),
+		newMappingTuple([]string{"config", "--get", "branch."}, c.BranchRemote),
+		newMappingTuple([]string{"show"}, c.Show),
+		// must be the last because this is the prefix of the other remote subcommands
+		newMappingTuple([]string{"remote"}, c.Remotes),
+	}...)
 }
 
 type mappingTuple struct {
@@ -16,24 +16,50 @@ func TestEndToEnd(t *testing.T) {
 	defer e.close()
 
 	const (
-		defaultBranch   = "master"
-		remoteOriginURL = "remote-origin"
-		headObjectName  = "head-object"
-		showPrefix      = "show-prefix"
-		relativePath    = "relative-path"
-		describeTag     = "describe-tag"
-		showCurrent     = "show-current"
-		commitHash      = "commit-hash"
+		defaultBranch       = "master"
+		remoteOriginURL     = "remote-origin"
+		remoteOriginPushURL = "remote-origin-push"
+		headObjectName      = "head-object"
+		showPrefix          = "show-prefix"
+		showToplevel        = "show-toplevel"
+		relativePath        = "relative-path"
+		describeTag         = "describe-tag"
+		showCurrent         = "show-current"
+		commitHash          = "commit-hash"
+		configGetRegexp     = "config-get-regexp"
+		configFileGetRegexp = "config-file-get-regexp"
+		symbolicFullName    = "symbolic-full-name"
+		revParseCommit      = "rev-parse-commit"
+		revListBoundary     = "rev-list-boundary"
+		forEachRefContains  = "for-each-ref-contains"
+		remotes             = "remotes"
+		branchRemote        = "branch-remote"
+		show                = "show"
+		upstreamURL         = "upstream-url"
 	)
-	envBytes, _ := json.Marshal(map[string]string{
-		"default_branch":    defaultBranch,
-		"remote_origin_url": remoteOriginURL,
-		"head_object_name":  headObjectName,
-		"show_prefix":       showPrefix,
-		"relative_path":     relativePath,
-		"describe_tag":      describeTag,
-		"show_current":      showCurrent,
-		"commit_hash":       commitHash,
+	envBytes, _ := json.Marshal(map[string]any{
+		"default_branch":         defaultBranch,
+		"remote_origin_url":      remoteOriginURL,
+		"remote_origin_push_url": remoteOriginPushURL,
+		"head_object_name":       headObjectName,
+		"show_prefix":            showPrefix,
+		"show_toplevel":          showToplevel,
+		"relative_path":          relativePath,
+		"describe_tag":           describeTag,
+		"show_current":           showCurrent,
+		"commit_hash":            commitHash,
+		"config_get_regexp":      configGetRegexp,
+		"config_file_get_regexp": configFileGetRegexp,
+		"symbolic_full_name":     symbolicFullName,
+		"rev_parse_commit":       revParseCommit,
+		"rev_list_boundary":      revListBoundary,
+		"for_each_ref_contains":  forEachRefContains,
+		"remotes":                remotes,
+		"branch_remote":          branchRemote,
+		"show":                   show,
+		"remote_urls": map[string]string{
+			"upstream": upstreamURL,
+		},
 	})
 	envSlices := []string{
 		fmt.Sprintf("GBROWSE_GIT=%s", e.git),
@@ -53,9 +79,14 @@ func TestEndToEnd(t *testing.T) {
 			},
 			{
 				name: "RemoteOriginURL",
-				args: []string{"config", "--get", "remote.origin.url"},
+				args: []string{"remote", "get-url", "origin"},
 				want: remoteOriginURL,
 			},
+			{
+				name: "RemoteOriginPushURL",
+				args: []string{"remote", "get-url", "--push", "origin"},
+				want: remoteOriginPushURL,
+			},
 			{
 				name: "HeadObjectName",
 				args: []string{"rev-parse", "--abbrev-ref", "@"},
@@ -66,6 +97,11 @@ func TestEndToEnd(t *testing.T) {
 				args: []string{"rev-parse", "--show-prefix"},
 				want: showPrefix,
 			},
+			{
+				name: "TopLevel",
+				args: []string{"rev-parse", "--show-toplevel"},
+				want: showToplevel,
+			},
 			{
 				name: "RelativePath",
 				args: []string{"ls-files", "--full-name"},
@@ -86,6 +122,61 @@ func TestEndToEnd(t *testing.T) {
 				args: []string{"rev-parse", "@"},
 				want: commitHash,
 			},
+			{
+				name: "ConfigGetRegexp",
+				args: []string{"config", "--get-regexp", `^gbrowse\.`},
+				want: configGetRegexp,
+			},
+			{
+				name: "ConfigFileGetRegexp",
+				args: []string{"config", "--file", "config", "--get-regexp", `^gbrowse\.`},
+				want: configFileGetRegexp,
+			},
+			{
+				name: "SymbolicFullName",
+				args: []string{"rev-parse", "--symbolic-full-name", "v1"},
+				want: symbolicFullName,
+			},
+			{
+				name: "RevParseCommit",
+				args: []string{"rev-parse", "--verify", "HEAD~1^{commit}"},
+				want: revParseCommit,
+			},
+			{
+				name: "PushedAncestor",
+				args: []string{"rev-list", "--boundary", "commit-hash", "--not", "--remotes=origin"},
+				want: revListBoundary,
+			},
+			{
+				name: "IsPushed",
+				args: []string{"for-each-ref", "--count=1", "--format=%(refname)", "--contains", "commit-hash", "refs/remotes/origin"},
+				want: forEachRefContains,
+			},
+			{
+				name: "Remotes",
+				args: []string{"remote"},
+				want: remotes,
+			},
+			{
+				name: "BranchRemote",
+				args: []string{"config", "--get", "branch.main.remote"},
+				want: branchRemote,
+			},
+			{
+				name: "Show",
+				args: []string{"show", "commit-hash:dir/file"},
+				want: show,
+			},
+			{
+				name: "RemoteURL",
+				args: []string{"remote", "get-url", "upstream"},
+				want: upstreamURL,
+			},
+			{
+				name: "RemotePushURL",
+				args: []string{"remote", "get-url", "--push", "upstream"},
+				want: upstreamURL,
+			},
 		} {
 			t.Run(tc.name, func(t *testing.T) {
 				output, err := run(envSlices, e.git, tc.args...)
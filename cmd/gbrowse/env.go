@@ -3,23 +3,36 @@ package main
 import (
 	"log/slog"
 	"os"
+	"path/filepath"
 
 	"github.com/berquerant/gbrowse/ctxlog"
 	"github.com/berquerant/gbrowse/env"
 )
 
 type envConfig struct {
-	Git     string
-	IsDebug bool
+	Git        string
+	IsDebug    bool
+	ConfigFile string
+	SSHConfig  string
 }
 
 func newEnvConfig() *envConfig {
 	var c envConfig
 	c.Git = env.GetOr("GIT", "git")
 	c.IsDebug = env.GetOr("DEBUG", "") != ""
+	c.ConfigFile = env.GetOr("GBROWSE_CONFIG", "")
+	c.SSHConfig = env.GetOr("GBROWSE_SSH_CONFIG", defaultSSHConfig())
 	return &c
 }
 
+func defaultSSHConfig() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".ssh", "config")
+}
+
 func (c *envConfig) logLevel() slog.Level {
 	if c.IsDebug {
 		return slog.LevelDebug
@@ -29,10 +42,11 @@ func (c *envConfig) logLevel() slog.Level {
 
 func (c *envConfig) logHandlerOptions() *slog.HandlerOptions {
 	return &slog.HandlerOptions{
-		Level: c.logLevel(),
+		Level:       c.logLevel(),
+		ReplaceAttr: ctxlog.ReplaceAttr,
 	}
 }
 
 func (c *envConfig) logger() ctxlog.Logger {
-	return ctxlog.New(slog.New(slog.NewJSONHandler(os.Stdout, c.logHandlerOptions())))
+	return ctxlog.New(slog.New(slog.NewJSONHandler(os.Stderr, c.logHandlerOptions())))
 }
@@ -1,28 +1,61 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 
 	"github.com/berquerant/gbrowse/browse"
 	"github.com/berquerant/gbrowse/ctxlog"
 	"github.com/berquerant/gbrowse/git"
 	"github.com/berquerant/gbrowse/parse"
+	"github.com/berquerant/gbrowse/symbol"
+	"github.com/berquerant/gbrowse/trace"
 	"github.com/berquerant/gbrowse/urlx"
 )
 
 const usage = `gbrowse - Open the repo in the browser
 
 Usage:
-  gbrowse [flags] [target]
+  gbrowse [flags] [target...]
+  gbrowse [flags] -- [path...]
+  gbrowse [flags] -trace < TRACE
 
-  The target is PATH or FILE:LINUM.
+  The target is PATH, FILE:LINUM or FILE:LINUM-END.
   gbrowse PATH opens the PATH of the repo.
   gbrowse FILE:LINUM opens the line LINUM of the FILE of the repo.
+  gbrowse FILE:LINUM-END opens the lines from LINUM to END of the FILE of the repo.
+  gbrowse FILE:/PATTERN/ opens the first line matching the regular expression PATTERN
+  in the FILE at the ref, gbrowse FILE:/PATTERN/,/END/ opens the range to the line matching END.
+  gbrowse FILE:#OFFSET opens the line at the byte OFFSET of the FILE like gopls,
+  gbrowse FILE:#OFFSET,#END opens the lines from OFFSET to END.
+  gbrowse FILE.go#NAME opens the declaration NAME of Go in the FILE.go.
+  gbrowse DIR.NAME opens the declaration NAME of the Go package in the DIR, e.g. gbrowse ./urlx.Build.
+  NAME is a function, a type, a variable, a constant or a method like Type.Method.
+  gbrowse URI opens the file of the URI of the editors,
+  file:///PATH, vscode://file/PATH:LINUM:COLUMN or idea://open?file=PATH&line=LINUM&column=COLUMN.
   gbrowse opens the directory of the repo.
+  gbrowse TARGET... opens the targets, -print prints the urls line by line.
+  The exit status is 1 if any target fails.
+  gbrowse -stdin reads the targets from stdin, e.g. git grep -n foo | gbrowse -stdin.
+  The lines of the quickfix list of vim like FILE|LINUM col COLUMN| TEXT are also accepted.
+  gbrowse -trace reads a Go stack trace like a panic or the output of go test from stdin
+  and prints it appending the urls to the frames in the repo, e.g. \t/path/to/repo/main.go:10 +0x1d URL.
+  The other lines are printed unchanged.
+
+  The line can be followed by :COLUMN and :MESSAGE like the output of compilers and grep,
+  e.g. gbrowse main.go:10:5: undefined: x.
+  The path can contain colons, the line starts at the last colon followed by a number
+  except the column, the target is the path if it exists, e.g. gbrowse logs/12:30:45.txt.
+  \: is a literal colon, e.g. gbrowse 'dir/2024\:10' opens the file dir/2024:10.
+  The targets after -- are literal paths, e.g. gbrowse -- dir/2024:10.
 
 Environment variables:
   GIT
@@ -31,6 +64,52 @@ Environment variables:
   DEBUG
     enable debug log if set.
 
+  GBROWSE_CONFIG
+    config file in the git config format, overridden by git config.
+
+  GBROWSE_SSH_CONFIG
+    ssh config file to resolve the host aliases of the ssh remotes, default is ~/.ssh/config.
+
+Git config:
+  gbrowse.<host>.forge
+    forge of the host, default is guessed from the host name.
+    one of github, gitlab, bitbucket, bitbucket-server, azure,
+    gitea (forgejo, codeberg), gitiles, gerrit, sourcehut, cgit.
+    <host> is a host name, a glob pattern of host names
+    or a regular expression enclosed in slashes, e.g. gbrowse."/^ssh\\.(.+)$/".forge.
+
+  gbrowse.<host>.baseURL
+    url of the web ui of the host, e.g. https://git.corp.example.
+    $1 is replaced by the submatch of the regular expression <host>, e.g. https://$1.
+    default is https://<host>.
+
+  gbrowse.<host>.pathPrefix
+    path of the web ui under baseURL, e.g. ghe for https://git.corp.example/ghe/owner/repo.
+
+  gbrowse.<host>.gitilesRoot
+    url of the gitiles web root of the host, e.g. https://gerrit.example.com/plugins/gitiles.
+    default is /plugins/gitiles of the host for gerrit, the root of the host for gitiles.
+
+  gbrowse.<host>.fileTemplate
+  gbrowse.<host>.dirTemplate
+  gbrowse.<host>.lineTemplate
+  gbrowse.<host>.rangeTemplate
+    text/template of the url of a file, a directory, a line and a range of lines.
+    overrides the forge, e.g. {{.RepoURL}}/view/{{.Ref}}/{{.Path}}#line-{{.Linum}}.
+    available fields:
+      .RepoURL  url of the repository
+      .BaseURL  url of the host
+      .Host     host of the repository
+      .Repo     path of the repository on the host, e.g. owner/repo
+      .Ref      commit, branch or tag
+      .RefType  kind of the ref, one of commit, branch, tag
+      .Path     path from the root of the repository
+      .IsDir    true if the path is a directory
+      .Linum    line number
+      .EndLinum last line number of the range
+      .Column   column number of the line
+    available functions: pathEscape, queryEscape, lower, upper, trimPrefix, trimSuffix.
+
 Flags:`
 
 func Usage() {
@@ -41,6 +120,13 @@ func Usage() {
 func main() {
 	var (
 		printOnly = flag.Bool("print", false, "only print generated url")
+		ref       = flag.String("ref", urlx.RefModeCommit, "ref to open; commit, branch (current branch), default (default branch), tag (latest tag) or a ref expression")
+		remote    = flag.String("remote", "", "remote to open, default is the remote of the upstream of the current branch or the first remote")
+		push      = flag.Bool("push", false, "use the push url of the remote instead of the fetch url")
+		stdin     = flag.Bool("stdin", false, "read targets from stdin line by line and print one url per line, an empty line for a failed target or a blank line")
+		nul       = flag.Bool("0", false, "targets of -stdin and urls are delimited by NUL instead of newline")
+		traceMode = flag.Bool("trace", false, "read a Go stack trace from stdin and print it with the urls of the frames in the repo")
+		unpushed  = flag.String("unpushed", urlx.UnpushedWarn, "behavior when the commit is not pushed; warn, fallback (open the nearest pushed ancestor, exit with 3) or error")
 		envConfig = newEnvConfig()
 		logger    = envConfig.logger()
 	)
@@ -59,16 +145,56 @@ func main() {
 
 	run(ctxlog.With(context.Background(), logger), &args{
 		envConfig: envConfig,
-		target:    flag.Arg(0),
+		targets:   flag.Args(),
+		literal:   isLiteral(os.Args[1:]),
+		stdin:     *stdin,
+		nul:       *nul,
+		trace:     *traceMode,
 		printOnly: *printOnly,
+		ref:       *ref,
+		unpushed:  *unpushed,
+		remote:    *remote,
+		push:      *push,
 	}).exit()
 }
 
+// isLiteral returns true if the flags are terminated by --, the targets follow it.
+// Scans the arguments like flag.Parse, so the value of a flag like -ref -- is not the terminator.
+func isLiteral(args []string) bool {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return true
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			// the first target
+			return false
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		f := flag.Lookup(name)
+		if f == nil {
+			return false
+		}
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			continue
+		}
+		// skip the value
+		i++
+	}
+	return false
+}
+
 type exitCode int
 
 const (
-	eSuccess exitCode = iota
-	eFailure
+	eSuccess exitCode = 0
+	eFailure exitCode = 1
+	// eFallback means success with the pushed ancestor instead of the unpushed commit.
+	// Not 2, the exit status of the usage error of flag.
+	eFallback exitCode = 3
 )
 
 func (c exitCode) exit() {
@@ -77,8 +203,19 @@ func (c exitCode) exit() {
 
 type args struct {
 	envConfig *envConfig
-	target    string
+	targets   []string
+	// literal means the targets are paths without the line.
+	literal bool
+	// stdin means the targets are read from stdin.
+	stdin bool
+	nul   bool
+	// trace means stdin is a stack trace to annotate.
+	trace     bool
 	printOnly bool
+	ref       string
+	unpushed  string
+	remote    string
+	push      bool
 }
 
 func run(ctx context.Context, args *args) exitCode {
@@ -86,37 +223,250 @@ func run(ctx context.Context, args *args) exitCode {
 
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
 	defer stop()
-	target, err := parse.ReadTarget(args.target)
-	if err != nil {
-		logger.Error("parse target",
-			ctxlog.Err(err),
+
+	if args.trace {
+		return runTrace(ctx, args)
+	}
+
+	delim := byte('\n')
+	if args.nul {
+		delim = 0
+	}
+	values := args.targets
+	if args.stdin {
+		var err error
+		if values, err = readRecords(os.Stdin, delim); err != nil {
+			logger.Error("read stdin",
+				ctxlog.Err(err),
+			)
+			return eFailure
+		}
+	} else if len(values) == 0 {
+		// the directory of the repo
+		values = []string{""}
+	}
+
+	var (
+		// nil if the target is invalid
+		targets = make([]*parse.Target, len(values))
+		valid   int
+		failed  bool
+	)
+	for i, value := range values {
+		if args.stdin && value == "" {
+			// an empty url for the blank line
+			continue
+		}
+		target, err := readTarget(value, args.literal)
+		if err != nil {
+			logger.Error("parse target",
+				ctxlog.S("target", value),
+				ctxlog.Err(err),
+			)
+			failed = true
+			continue
+		}
+		targets[i] = target
+		valid++
+	}
+	if valid == 0 {
+		if args.stdin {
+			fmt.Print(strings.Repeat(string(delim), len(values)))
+		}
+		if failed {
+			return eFailure
+		}
+		return eSuccess
+	}
+
+	gitCommand := git.New(git.WithGitCommand(args.envConfig.Git))
+	repo, success, ok := newRepo(ctx, gitCommand, args)
+	if !ok {
+		return eFailure
+	}
+
+	var printed int
+	for _, target := range targets {
+		var (
+			targetURL string
+			err       error
 		)
+		if target != nil {
+			if targetURL, err = repo.Build(ctx, target); err != nil {
+				logger.Error("build url",
+					ctxlog.S("target", target.String()),
+					ctxlog.Err(err),
+				)
+				failed = true
+			}
+		}
+
+		if args.stdin {
+			// one url per input record, empty if failed
+			fmt.Print(targetURL + string(delim))
+			continue
+		}
+		if targetURL == "" {
+			continue
+		}
+		if args.printOnly {
+			// one url per line
+			if printed > 0 {
+				fmt.Print(string(delim))
+			}
+			fmt.Print(targetURL)
+			printed++
+			continue
+		}
+
+		if err := browse.Run(ctx, targetURL); err != nil {
+			logger.Error("browse",
+				ctxlog.S("target", target.String()),
+				ctxlog.Err(err),
+			)
+			failed = true
+		}
+	}
+
+	if failed {
 		return eFailure
 	}
+	return success
+}
 
-	gitCommand := git.New(git.WithGitCommand(args.envConfig.Git))
-	targetURL, err := urlx.Build(
+// newRepo resolves the repository.
+// Returns the exit code on success, eFallback if the unpushed commit is replaced.
+func newRepo(ctx context.Context, gitCommand git.Git, args *args) (*urlx.Repo, exitCode, bool) {
+	logger := ctxlog.From(ctx)
+	repo, err := urlx.NewRepo(
 		ctx,
 		gitCommand,
-		target,
+		urlx.WithConfigFile(args.envConfig.ConfigFile),
+		urlx.WithRef(args.ref),
+		urlx.WithUnpushed(args.unpushed),
+		urlx.WithRemote(args.remote),
+		urlx.WithPush(args.push),
+		urlx.WithSSHConfig(args.envConfig.SSHConfig),
 	)
 	if err != nil {
-		logger.Error("build url",
+		logger.Error("resolve repository",
 			ctxlog.Err(err),
 		)
-		return eFailure
+		return nil, eFailure, false
+	}
+	logger.Debug("repository",
+		ctxlog.S("remote", repo.Remote()),
+	)
+	success := eSuccess
+	if commit, ok := repo.Unpushed(); ok {
+		logger.Debug("fallback from unpushed commit",
+			ctxlog.S("commit", commit),
+		)
+		success = eFallback
 	}
+	return repo, success, true
+}
 
-	if args.printOnly {
-		fmt.Print(targetURL)
-		return eSuccess
+// runTrace prints the stack trace from stdin, appending the urls to the frames in the repo.
+func runTrace(ctx context.Context, args *args) exitCode {
+	logger := ctxlog.From(ctx)
+
+	gitCommand := git.New(git.WithGitCommand(args.envConfig.Git))
+	top, err := gitCommand.TopLevel(ctx)
+	if err != nil {
+		logger.Error("resolve repository",
+			ctxlog.Err(err),
+		)
+		return eFailure
+	}
+	repo, success, ok := newRepo(ctx, gitCommand, args)
+	if !ok {
+		return eFailure
 	}
 
-	if err := browse.Run(ctx, targetURL); err != nil {
-		logger.Error("browse",
+	var failed bool
+	if err := trace.Annotate(os.Stdin, os.Stdout, func(frame *trace.Frame) string {
+		path := filepath.FromSlash(frame.Path)
+		if !isInside(top, path) {
+			return ""
+		}
+		target := parse.NewTarget(path, frame.Linum)
+		u, err := repo.Build(ctx, target)
+		if err != nil {
+			logger.Error("build url",
+				ctxlog.S("target", target.String()),
+				ctxlog.Err(err),
+			)
+			failed = true
+			return ""
+		}
+		return u
+	}); err != nil {
+		logger.Error("read stdin",
 			ctxlog.Err(err),
 		)
 		return eFailure
 	}
-	return eSuccess
+
+	if failed {
+		return eFailure
+	}
+	return success
+}
+
+// isInside returns true if the absolute path is in the directory.
+func isInside(dir, path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func readTarget(value string, literal bool) (*parse.Target, error) {
+	if literal {
+		return parse.NewPathTarget(value), nil
+	}
+	target, err := parse.ReadTarget(value)
+	if err == nil {
+		if _, ok := target.Linum(); ok {
+			// not a symbol but FILE:LINUM like grep output, e.g. README.md:1:see a.go#Foo
+			return target, nil
+		}
+	}
+	if sym, ok := symbol.Read(value); ok {
+		loc, err := sym.Locate()
+		if err != nil {
+			return nil, err
+		}
+		return parse.NewRangeTarget(loc.File, loc.Linum, loc.EndLinum), nil
+	}
+	return target, err
+}
+
+// readRecords reads the records delimited by delim.
+func readRecords(r io.Reader, delim byte) ([]string, error) {
+	var (
+		records []string
+		reader  = bufio.NewReader(r)
+	)
+	for {
+		record, err := reader.ReadString(delim)
+		if record != "" {
+			record = strings.TrimSuffix(record, string(delim))
+			if delim == '\n' {
+				record = strings.TrimSuffix(record, "\r")
+			}
+			records = append(records, record)
+		}
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
 }
@@ -0,0 +1,22 @@
+package sym
+
+func F() {}
+
+type T struct{}
+
+func (T) M() {
+}
+
+func (*T) M2() {
+}
+
+func (*T) m() {
+}
+
+func (*T) N() {
+}
+
+type U struct{}
+
+func (U) N() {
+}
@@ -24,6 +24,7 @@ func With(ctx context.Context, instance Logger) context.Context {
 
 type Logger interface {
 	Error(msg string, attrs ...Attr)
+	Warn(msg string, attrs ...Attr)
 	Info(msg string, attrs ...Attr)
 	Debug(msg string, attrs ...Attr)
 }
@@ -54,6 +55,13 @@ func (l *logger) Info(msg string, attrs ...Attr) {
 	l.logAttrs(slog.LevelInfo, msg, attrs...)
 }
 
+func (l *logger) Warn(msg string, attrs ...Attr) {
+	if l == nil || l.l == nil {
+		return
+	}
+	l.logAttrs(slog.LevelWarn, msg, attrs...)
+}
+
 func (l *logger) Error(msg string, attrs ...Attr) {
 	if l == nil || l.l == nil {
 		return
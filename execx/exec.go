@@ -15,11 +15,15 @@ func Run(ctx context.Context, command string, arg ...string) (string, error) {
 	var (
 		logger = ctxlog.From(ctx)
 		cmd    = exec.CommandContext(ctx, command, arg...)
+		// args is cmd.Args without credentials
+		args   = redactAll(cmd.Args)
 		genErr = func(err error) error {
-			err = fmt.Errorf("failed to run %s, %w", strings.Join(cmd.Args, " "), err)
+			err = &redactedError{
+				err: fmt.Errorf("failed to run %s, %w", strings.Join(args, " "), err),
+			}
 			logger.Debug("failed to execx.Run",
-				ctxlog.S("command", strings.Join(cmd.Args, " ")),
-				ctxlog.SS("command_list", cmd.Args),
+				ctxlog.S("command", strings.Join(args, " ")),
+				ctxlog.SS("command_list", args),
 				ctxlog.Err(err),
 			)
 			return err
@@ -37,9 +41,25 @@ func Run(ctx context.Context, command string, arg ...string) (string, error) {
 
 	result := strings.TrimSuffix(stdout.String(), "\n")
 	logger.Debug("execx.Run",
-		ctxlog.S("command", strings.Join(cmd.Args, " ")),
-		ctxlog.SS("command_list", cmd.Args),
-		ctxlog.S("result", result),
+		ctxlog.S("command", strings.Join(args, " ")),
+		ctxlog.SS("command_list", args),
+		ctxlog.S("result", ctxlog.Redact(result)),
 	)
 	return result, nil
 }
+
+func redactAll(xs []string) []string {
+	ys := make([]string, len(xs))
+	for i, x := range xs {
+		ys[i] = ctxlog.Redact(x)
+	}
+	return ys
+}
+
+// redactedError hides the credentials in the message of the error, e.g. in stderr.
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string { return ctxlog.Redact(e.err.Error()) }
+func (e *redactedError) Unwrap() error { return e.err }
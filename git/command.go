@@ -2,24 +2,53 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os/exec"
 	"strings"
 
 	"github.com/berquerant/gbrowse/execx"
 )
 
 //go:generate go tool goconfig -field "GitCommand string" -option -output config_generated.go
+//go:generate go tool dataclass -type "ConfigEntry" -field "Key string|Value string" -output config_entry_dataclass_generated.go
 
 // Git is git runner.
 type Git interface {
-	DefaultBranch(ctx context.Context) (string, error)
-	RemoteOriginURL(ctx context.Context) (string, error)
+	DefaultBranch(ctx context.Context, remote string) (string, error)
+	// RemoteURL returns the url of the remote rewritten by url.<base>.insteadOf.
+	// Returns the push url rewritten by url.<base>.pushInsteadOf if push is true.
+	RemoteURL(ctx context.Context, remote string, push bool) (string, error)
+	// Remotes returns the names of the remotes.
+	Remotes(ctx context.Context) ([]string, error)
+	// BranchRemote returns the remote the branch tracks.
+	// Returns empty if the branch has no upstream.
+	BranchRemote(ctx context.Context, branch string) (string, error)
 	HeadObjectName(ctx context.Context) (string, error)
 	ShowPrefix(ctx context.Context) (string, error)
+	// TopLevel returns the absolute path of the root of the working tree.
+	TopLevel(ctx context.Context) (string, error)
 	RelativePath(ctx context.Context, path string) (string, error)
 	DescribeTag(ctx context.Context) (string, error)
 	ShowCurrent(ctx context.Context) (string, error)
 	CommitHash(ctx context.Context) (string, error)
+	// SymbolicFullName returns the full ref name of the rev like refs/heads/main.
+	// Returns empty if the rev is not a ref.
+	SymbolicFullName(ctx context.Context, rev string) (string, error)
+	// RevParseCommit returns the commit hash of the rev.
+	RevParseCommit(ctx context.Context, rev string) (string, error)
+	// IsPushed returns true if the commit is reachable from the remote-tracking refs of the remote.
+	IsPushed(ctx context.Context, remote, commit string) (bool, error)
+	// PushedAncestor returns the nearest ancestor of the commit reachable from the remote-tracking refs of the remote.
+	// Returns the commit itself if pushed, empty if no ancestors are pushed.
+	PushedAncestor(ctx context.Context, remote, commit string) (string, error)
+	// ConfigGetRegexp returns the config entries whose keys match the pattern.
+	ConfigGetRegexp(ctx context.Context, pattern string) ([]ConfigEntry, error)
+	// ConfigFileGetRegexp returns the config entries of the file whose keys match the pattern.
+	ConfigFileGetRegexp(ctx context.Context, file, pattern string) ([]ConfigEntry, error)
+	// Show returns the content of the file at the rev.
+	// The path is relative to the root of the repository.
+	Show(ctx context.Context, rev, path string) (string, error)
 }
 
 type gitImpl struct {
@@ -38,8 +67,8 @@ func New(opt ...ConfigOption) Git {
 	}
 }
 
-func (g *gitImpl) DefaultBranch(ctx context.Context) (string, error) {
-	r, err := g.run(ctx, "remote", "show", "origin")
+func (g *gitImpl) DefaultBranch(ctx context.Context, remote string) (string, error) {
+	r, err := g.run(ctx, "remote", "show", remote)
 	if err != nil {
 		return "", err
 	}
@@ -59,8 +88,33 @@ func (g *gitImpl) DefaultBranch(ctx context.Context) (string, error) {
 	return "", fmt.Errorf("cannot find default branch from %s", r)
 }
 
-func (g *gitImpl) RemoteOriginURL(ctx context.Context) (string, error) {
-	return g.run(ctx, "config", "--get", "remote.origin.url")
+func (g *gitImpl) RemoteURL(ctx context.Context, remote string, push bool) (string, error) {
+	if push {
+		return g.run(ctx, "remote", "get-url", "--push", remote)
+	}
+	return g.run(ctx, "remote", "get-url", remote)
+}
+
+func (g *gitImpl) Remotes(ctx context.Context) ([]string, error) {
+	r, err := g.run(ctx, "remote")
+	if err != nil {
+		return nil, err
+	}
+	var remotes []string
+	for p := range strings.SplitSeq(r, "\n") {
+		if p != "" {
+			remotes = append(remotes, p)
+		}
+	}
+	return remotes, nil
+}
+
+func (g *gitImpl) BranchRemote(ctx context.Context, branch string) (string, error) {
+	r, err := g.run(ctx, "config", "--get", "branch."+branch+".remote")
+	if isNoValue(err) {
+		return "", nil
+	}
+	return r, err
 }
 
 func (g *gitImpl) HeadObjectName(ctx context.Context) (string, error) {
@@ -71,6 +125,10 @@ func (g *gitImpl) ShowPrefix(ctx context.Context) (string, error) {
 	return g.run(ctx, "rev-parse", "--show-prefix")
 }
 
+func (g *gitImpl) TopLevel(ctx context.Context) (string, error) {
+	return g.run(ctx, "rev-parse", "--show-toplevel")
+}
+
 func (g *gitImpl) RelativePath(ctx context.Context, path string) (string, error) {
 	return g.run(ctx, "ls-files", "--full-name", path)
 }
@@ -87,6 +145,78 @@ func (g *gitImpl) CommitHash(ctx context.Context) (string, error) {
 	return g.run(ctx, "rev-parse", "@")
 }
 
+func (g *gitImpl) SymbolicFullName(ctx context.Context, rev string) (string, error) {
+	return g.run(ctx, "rev-parse", "--symbolic-full-name", rev)
+}
+
+func (g *gitImpl) RevParseCommit(ctx context.Context, rev string) (string, error) {
+	return g.run(ctx, "rev-parse", "--verify", rev+"^{commit}")
+}
+
+func (g *gitImpl) IsPushed(ctx context.Context, remote, commit string) (bool, error) {
+	// cheaper than rev-list which lists the whole history if the remote has no refs
+	r, err := g.run(ctx, "for-each-ref", "--count=1", "--format=%(refname)", "--contains", commit, "refs/remotes/"+remote)
+	if err != nil {
+		return false, err
+	}
+	return r != "", nil
+}
+
+func (g *gitImpl) PushedAncestor(ctx context.Context, remote, commit string) (string, error) {
+	// lists the unpushed commits and the pushed boundary commits prefixed by -
+	r, err := g.run(ctx, "rev-list", "--boundary", commit, "--not", "--remotes="+remote)
+	if err != nil {
+		return "", err
+	}
+	if r == "" {
+		return commit, nil
+	}
+	for p := range strings.SplitSeq(r, "\n") {
+		if x, ok := strings.CutPrefix(p, "-"); ok {
+			return x, nil
+		}
+	}
+	return "", nil
+}
+
+func (g *gitImpl) ConfigGetRegexp(ctx context.Context, pattern string) ([]ConfigEntry, error) {
+	return g.configGetRegexp(ctx, "--get-regexp", pattern)
+}
+
+func (g *gitImpl) ConfigFileGetRegexp(ctx context.Context, file, pattern string) ([]ConfigEntry, error) {
+	return g.configGetRegexp(ctx, "--file", file, "--get-regexp", pattern)
+}
+
+func (g *gitImpl) Show(ctx context.Context, rev, path string) (string, error) {
+	return g.run(ctx, "show", rev+":"+path)
+}
+
+func (g *gitImpl) configGetRegexp(ctx context.Context, arg ...string) ([]ConfigEntry, error) {
+	r, err := g.run(ctx, append([]string{"config"}, arg...)...)
+	if isNoValue(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ConfigEntry
+	for p := range strings.SplitSeq(r, "\n") {
+		if p == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(p, " ")
+		entries = append(entries, NewConfigEntry(key, value))
+	}
+	return entries, nil
+}
+
+// isNoValue returns true if git config exited with 1, that means the key was not found.
+func isNoValue(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == 1
+}
+
 func (g *gitImpl) run(ctx context.Context, arg ...string) (string, error) {
 	return execx.Run(ctx, g.config.GitCommand.Get(), arg...)
 }
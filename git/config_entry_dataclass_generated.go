@@ -0,0 +1,24 @@
+// Code generated by "dataclass -type ConfigEntry -field Key string|Value string -output config_entry_dataclass_generated.go"; DO NOT EDIT.
+
+package git
+
+type ConfigEntry interface {
+	Key() string
+	Value() string
+}
+type configEntry struct {
+	key   string
+	value string
+}
+
+func (s *configEntry) Key() string   { return s.key }
+func (s *configEntry) Value() string { return s.value }
+func NewConfigEntry(
+	key string,
+	value string,
+) ConfigEntry {
+	return &configEntry{
+		key:   key,
+		value: value,
+	}
+}
@@ -0,0 +1,59 @@
+package parse
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// ErrOffsetOutOfRange means that the offset exceeds the size of the file.
+var ErrOffsetOutOfRange = errors.New("offset out of range")
+
+// readOffsetTarget converts #OFFSET or #OFFSET,#END of the file into the line, the column and the end line.
+// The end is exclusive like the selections of the editors.
+func readOffsetTarget(path, value string) (*Target, error) {
+	m := offsetRegexp.FindStringSubmatch(value)
+	start, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil, err
+	}
+	end := -1
+	if m[2] != "" {
+		if end, err = strconv.Atoi(m[2]); err != nil {
+			return nil, err
+		}
+		if end < start {
+			return nil, fmt.Errorf("%w: #%d,#%d", ErrReversedRange, start, end)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	linum, column, err := offsetPosition(content, start)
+	if err != nil {
+		return nil, err
+	}
+	endLinum := -1
+	if end > start {
+		if endLinum, _, err = offsetPosition(content, end-1); err != nil {
+			return nil, err
+		}
+	}
+	return NewLineTarget(path, linum, endLinum, column), nil
+}
+
+// offsetPosition returns the line and the column in bytes of the offset.
+// The offset can be the size of the content, the end of the file.
+func offsetPosition(content []byte, offset int) (int, int, error) {
+	if offset > len(content) {
+		return 0, 0, fmt.Errorf("%w: #%d, size %d", ErrOffsetOutOfRange, offset, len(content))
+	}
+	head := content[:offset]
+	linum := bytes.Count(head, []byte("\n")) + 1
+	column := offset - (bytes.LastIndexByte(head, '\n') + 1) + 1
+	return linum, column, nil
+}
@@ -1,12 +1,15 @@
 package parse
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
-//go:generate go tool dataclass -type "InternalTarget" -field "Path string|Linum int" -output parameter_dataclass_generated.go
+//go:generate go tool dataclass -type "InternalTarget" -field "Path string|Linum int|EndLinum int|Column int|Pattern string|EndPattern string" -output parameter_dataclass_generated.go
 
 type Target struct {
 	value InternalTarget
@@ -21,16 +24,67 @@ func (t *Target) Linum() (int, bool) {
 	return v, v > 0
 }
 
+// EndLinum returns the last line number of the range.
+func (t *Target) EndLinum() (int, bool) {
+	v := t.value.EndLinum()
+	return v, v > 0
+}
+
+// Column returns the column number of the line.
+func (t *Target) Column() (int, bool) {
+	v := t.value.Column()
+	return v, v > 0
+}
+
+// Pattern returns the regular expressions of the line and the end of the range.
+// The end pattern is empty if not a range.
+func (t *Target) Pattern() (string, string, bool) {
+	return t.value.Pattern(), t.value.EndPattern(), t.value.Pattern() != ""
+}
+
 func (t *Target) String() string {
+	if t.value.Pattern() != "" {
+		s := fmt.Sprintf("%s:/%s/", t.value.Path(), escapeSlash(t.value.Pattern()))
+		if t.value.EndPattern() != "" {
+			s += fmt.Sprintf(",/%s/", escapeSlash(t.value.EndPattern()))
+		}
+		return s
+	}
 	if t.value.Linum() < 1 {
 		return t.value.Path()
 	}
-	return fmt.Sprintf("%s:%d", t.value.Path(), t.value.Linum())
+	s := fmt.Sprintf("%s:%d", t.value.Path(), t.value.Linum())
+	if t.value.EndLinum() > 0 {
+		s += fmt.Sprintf("-%d", t.value.EndLinum())
+	}
+	if t.value.Column() > 0 {
+		s += fmt.Sprintf(":%d", t.value.Column())
+	}
+	return s
 }
 
 func NewTarget(path string, linum int) *Target {
+	return NewRangeTarget(path, linum, -1)
+}
+
+// NewRangeTarget returns the target of the lines from linum to endLinum.
+func NewRangeTarget(path string, linum, endLinum int) *Target {
+	return NewLineTarget(path, linum, endLinum, -1)
+}
+
+// NewLineTarget returns the target of the lines from linum to endLinum and the column of the line.
+// Non positive endLinum and column mean no range and no column.
+func NewLineTarget(path string, linum, endLinum, column int) *Target {
 	return &Target{
-		value: NewInternalTarget(path, linum),
+		value: NewInternalTarget(path, linum, endLinum, column, "", ""),
+	}
+}
+
+// NewPatternTarget returns the target of the first line matching the pattern,
+// or the range to the first line matching endPattern if not empty.
+func NewPatternTarget(path, pattern, endPattern string) *Target {
+	return &Target{
+		value: NewInternalTarget(path, -1, -1, -1, pattern, endPattern),
 	}
 }
 
@@ -38,14 +92,235 @@ func NewPathTarget(path string) *Target {
 	return NewTarget(path, -1)
 }
 
+var (
+	ErrZeroLine      = errors.New("line number must be positive")
+	ErrReversedRange = errors.New("end line must not be less than start line")
+)
+
+var (
+	// lineRegexp matches LINUM or LINUM-END.
+	lineRegexp = regexp.MustCompile(`^[0-9]+(-[0-9]+)?$`)
+	// offsetRegexp matches #OFFSET or #OFFSET,#END.
+	offsetRegexp = regexp.MustCompile(`^#([0-9]+)(?:,#([0-9]+))?$`)
+	// patternRegexp matches /PATTERN/ or /PATTERN/,/END/, \/ is a literal slash.
+	patternRegexp = regexp.MustCompile(`^/((?:[^/\\]|\\.)+)/(?:,/((?:[^/\\]|\\.)+)/)?$`)
+	// quickfixRegexp matches FILE|LINUM[-END][ col COLUMN[-END]]| and FILE||, the lines of the quickfix list of vim.
+	quickfixRegexp = regexp.MustCompile(`^([^|]+)\|(?:([0-9]+(?:-[0-9]+)?)(?: col ([0-9]+)(?:-[0-9]+)?)?)?\|`)
+)
+
+// ReadTarget parses PATH, FILE:LINUM or FILE:LINUM-END,
+// followed by the optional :COLUMN and :MESSAGE like the output of compilers and grep,
+// e.g. main.go:10:5: undefined: x.
+//
+// The path ends at the last colon followed by the line, the value is the path if it exists,
+// so the path can contain colons like docs/a:b.md, logs/12:30:45.txt and C:\src\x.go.
+// \: is a literal colon of the path like dir/2024\:10.
+//
+// FILE|LINUM col COLUMN| TEXT, the line of the quickfix list of vim, is also accepted.
+//
+// FILE:/PATTERN/ and FILE:/PATTERN/,/END/ are the lines matching the regular expressions.
+//
+// FILE:#OFFSET and FILE:#OFFSET,#END are the byte offsets of the file like gopls,
+// converted into the line and the column by reading the file.
+//
+// The URIs of the editors, file:///PATH, vscode://file/PATH:LINUM:COLUMN
+// and idea://open?file=PATH&line=LINUM&column=COLUMN are also accepted.
 func ReadTarget(value string) (*Target, error) {
-	if xs := strings.SplitN(value, ":", 2); len(xs) == 2 {
-		linum, err := strconv.Atoi(xs[1])
+	if t, ok, err := readURITarget(value); ok {
+		if err != nil {
+			return nil, fmt.Errorf("invalid target %s, %w", value, err)
+		}
+		return t, nil
+	}
+	return readTarget(value)
+}
+
+func readTarget(value string) (*Target, error) {
+	if m := quickfixRegexp.FindStringSubmatch(value); m != nil {
+		t, err := readQuickfixTarget(m[1], m[2], m[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid target %s, %w", value, err)
+		}
+		return t, nil
+	}
+	if path, pattern, endPattern, ok := splitPatternTarget(value); ok {
+		return NewPatternTarget(path, pattern, endPattern), nil
+	}
+	if path, rest, ok := cutTrailingTarget(value, offsetRegexp.MatchString); ok {
+		t, err := readOffsetTarget(path, rest)
 		if err != nil {
 			return nil, fmt.Errorf("invalid target %s, %w", value, err)
 		}
-		return NewTarget(xs[0], linum), nil
+		return t, nil
+	}
+	path, lines, ok := splitTarget(value)
+	if !ok {
+		return NewPathTarget(path), nil
+	}
+
+	xs := strings.SplitN(lines, ":", 3)
+	linum, endLinum, err := readLines(xs[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid target %s, %w", value, err)
+	}
+	column := -1
+	if len(xs) > 1 {
+		// not a column but a message if not a number
+		if x, err := strconv.Atoi(xs[1]); err == nil {
+			column = x
+		}
+	}
+	return NewLineTarget(path, linum, endLinum, column), nil
+}
+
+func readQuickfixTarget(path, lines, column string) (*Target, error) {
+	if lines == "" {
+		return NewPathTarget(path), nil
+	}
+	linum, endLinum, err := readLines(lines)
+	if err != nil {
+		return nil, err
+	}
+	col := -1
+	if column != "" {
+		if col, err = strconv.Atoi(column); err != nil {
+			return nil, err
+		}
+	}
+	return NewLineTarget(path, linum, endLinum, col), nil
+}
+
+// splitTarget splits the value at the unescaped colon followed by the line.
+// Returns the unescaped path and the rest after the colon.
+//
+// The value is the path if it exists.
+// Otherwise the numbers following the line like :LINUM:COLUMN are the tail of the line,
+// and the path ends at the last colon followed by the line whose path exists,
+// or at the last colon followed by the line if no such paths exist.
+func splitTarget(value string) (string, string, bool) {
+	if path := unescapeColon(value); exists(path) {
+		return path, "", false
+	}
+
+	var (
+		splits []int
+		// end of the last line or the number following it
+		end = -1
+	)
+	for i := 0; i < len(value); i++ {
+		if value[i] != ':' || (i > 0 && value[i-1] == '\\') {
+			continue
+		}
+		line, _, _ := strings.Cut(value[i+1:], ":")
+		if !lineRegexp.MatchString(line) {
+			continue
+		}
+		if i != end {
+			splits = append(splits, i)
+		}
+		end = i + 1 + len(line)
+	}
+	if len(splits) == 0 {
+		return unescapeColon(value), "", false
 	}
 
-	return NewPathTarget(value), nil
+	i := splits[len(splits)-1]
+	for j := len(splits) - 1; j >= 0; j-- {
+		if exists(unescapeColon(value[:splits[j]])) {
+			i = splits[j]
+			break
+		}
+	}
+	return unescapeColon(value[:i]), value[i+1:], true
+}
+
+func exists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// splitPatternTarget splits the value at the first unescaped colon followed by the patterns.
+func splitPatternTarget(value string) (string, string, string, bool) {
+	path, rest, ok := cutTrailingTarget(value, patternRegexp.MatchString)
+	if !ok {
+		return "", "", "", false
+	}
+	m := patternRegexp.FindStringSubmatch(rest)
+	return path, unescapeSlash(m[1]), unescapeSlash(m[2]), true
+}
+
+// cutTarget cuts the value at the first unescaped colon where the rest matches.
+// Returns the unescaped path and the rest after the colon.
+func cutTarget(value string, match func(rest string) bool) (string, string, bool) {
+	for i := 0; i < len(value); i++ {
+		if value[i] != ':' || (i > 0 && value[i-1] == '\\') {
+			continue
+		}
+		if rest := value[i+1:]; match(rest) {
+			return unescapeColon(value[:i]), rest, true
+		}
+	}
+	return unescapeColon(value), "", false
+}
+
+// cutTrailingTarget cuts the value like cutTarget
+// unless the path is FILE:LINUM, the rest is the text of the line like .gitignore:1:/vendor/ of grep.
+func cutTrailingTarget(value string, match func(rest string) bool) (string, string, bool) {
+	path, rest, ok := cutTarget(value, match)
+	if !ok {
+		return path, rest, false
+	}
+	if _, _, isLine := splitTarget(value[:len(value)-len(rest)-1]); isLine {
+		return unescapeColon(value), "", false
+	}
+	return path, rest, true
+}
+
+func unescapeSlash(pattern string) string {
+	return strings.ReplaceAll(pattern, `\/`, "/")
+}
+
+func escapeSlash(pattern string) string {
+	return strings.ReplaceAll(pattern, "/", `\/`)
+}
+
+func unescapeColon(path string) string {
+	return strings.ReplaceAll(path, `\:`, ":")
+}
+
+// readLines parses LINUM or LINUM-END.
+func readLines(value string) (int, int, error) {
+	start, end, ok := strings.Cut(value, "-")
+	if !ok {
+		linum, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, err
+		}
+		if linum < 1 {
+			return 0, 0, fmt.Errorf("%w: %d", ErrZeroLine, linum)
+		}
+		return linum, -1, nil
+	}
+	return readRange(start, end)
+}
+
+func readRange(start, end string) (int, int, error) {
+	linum, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	endLinum, err := strconv.Atoi(end)
+	if err != nil {
+		return 0, 0, err
+	}
+	if linum < 1 || endLinum < 1 {
+		return 0, 0, fmt.Errorf("%w: %d-%d", ErrZeroLine, linum, endLinum)
+	}
+	if endLinum < linum {
+		return 0, 0, fmt.Errorf("%w: %d-%d", ErrReversedRange, linum, endLinum)
+	}
+	return linum, endLinum, nil
 }
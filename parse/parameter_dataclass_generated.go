@@ -1,24 +1,44 @@
-// Code generated by "dataclass -type InternalTarget -field Path string|Linum int -output parameter_dataclass_generated.go"; DO NOT EDIT.
+// Code generated by "dataclass -type InternalTarget -field Path string|Linum int|EndLinum int|Column int|Pattern string|EndPattern string -output parameter_dataclass_generated.go"; DO NOT EDIT.
 
 package parse
 
 type InternalTarget interface {
 	Path() string
 	Linum() int
+	EndLinum() int
+	Column() int
+	Pattern() string
+	EndPattern() string
 }
 type internalTarget struct {
-	path  string
-	linum int
+	path       string
+	linum      int
+	endLinum   int
+	column     int
+	pattern    string
+	endPattern string
 }
 
-func (s *internalTarget) Path() string { return s.path }
-func (s *internalTarget) Linum() int   { return s.linum }
+func (s *internalTarget) Path() string       { return s.path }
+func (s *internalTarget) Linum() int         { return s.linum }
+func (s *internalTarget) EndLinum() int      { return s.endLinum }
+func (s *internalTarget) Column() int        { return s.column }
+func (s *internalTarget) Pattern() string    { return s.pattern }
+func (s *internalTarget) EndPattern() string { return s.endPattern }
 func NewInternalTarget(
 	path string,
 	linum int,
+	endLinum int,
+	column int,
+	pattern string,
+	endPattern string,
 ) InternalTarget {
 	return &internalTarget{
-		path:  path,
-		linum: linum,
+		path:       path,
+		linum:      linum,
+		endLinum:   endLinum,
+		column:     column,
+		pattern:    pattern,
+		endPattern: endPattern,
 	}
 }
@@ -1,6 +1,8 @@
 package parse_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/berquerant/gbrowse/parse"
@@ -9,10 +11,150 @@ import (
 
 func TestReadTarget(t *testing.T) {
 	t.Run("invalid target", func(t *testing.T) {
-		_, err := parse.ReadTarget("a:b")
+		_, err := parse.ReadTarget("a:99999999999999999999")
 		assert.NotNil(t, err)
 	})
 
+	t.Run("invalid range", func(t *testing.T) {
+		for _, tc := range []struct {
+			value string
+			err   error
+		}{
+			{value: "a:0", err: parse.ErrZeroLine},
+			{value: "a.go|0| x", err: parse.ErrZeroLine},
+			{value: "a:25-10", err: parse.ErrReversedRange},
+			{value: "a:0-10", err: parse.ErrZeroLine},
+			{value: "a:10-0", err: parse.ErrZeroLine},
+			{value: "a.go|25-10 col 5| x", err: parse.ErrReversedRange},
+		} {
+			_, err := parse.ReadTarget(tc.value)
+			if !assert.NotNil(t, err, tc.value) {
+				continue
+			}
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err, tc.value)
+			}
+		}
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "a.go")
+		if err := os.WriteFile(file, []byte("package a\n\nfunc f() {\n}\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tc := range []struct {
+			title string
+			value string
+			want  *parse.Target
+			err   error
+		}{
+			{
+				title: "line head",
+				value: file + ":#11",
+				want:  parse.NewLineTarget(file, 3, -1, 1),
+			},
+			{
+				title: "grep output like an offset",
+				value: file + ":12:#1234",
+				want:  parse.NewTarget(file, 12),
+			},
+			{
+				title: "column",
+				value: file + ":#16",
+				want:  parse.NewLineTarget(file, 3, -1, 6),
+			},
+			{
+				title: "range",
+				value: file + ":#11,#24",
+				want:  parse.NewLineTarget(file, 3, 4, 1),
+			},
+			{
+				title: "empty range",
+				value: file + ":#16,#16",
+				want:  parse.NewLineTarget(file, 3, -1, 6),
+			},
+			{
+				title: "end of file",
+				value: file + ":#24",
+				want:  parse.NewLineTarget(file, 5, -1, 1),
+			},
+			{
+				title: "out of range",
+				value: file + ":#25",
+				err:   parse.ErrOffsetOutOfRange,
+			},
+			{
+				title: "reversed",
+				value: file + ":#20,#10",
+				err:   parse.ErrReversedRange,
+			},
+			{
+				title: "not exist",
+				value: file + ".none:#1",
+				err:   os.ErrNotExist,
+			},
+		} {
+			t.Run(tc.title, func(t *testing.T) {
+				got, err := parse.ReadTarget(tc.value)
+				if tc.err != nil {
+					assert.ErrorIs(t, err, tc.err)
+					return
+				}
+				if assert.Nil(t, err) {
+					assert.Equal(t, tc.want.String(), got.String())
+				}
+			})
+		}
+	})
+
+	t.Run("existing path", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, x := range []string{"a.go", "logs/12:30:45.txt", "a:10:b.md"} {
+			file := filepath.Join(dir, x)
+			if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(file, nil, 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, tc := range []struct {
+			title string
+			value string
+			want  *parse.Target
+		}{
+			{
+				title: "path with time",
+				value: filepath.Join(dir, "logs/12:30:45.txt"),
+				want:  parse.NewPathTarget(filepath.Join(dir, "logs/12:30:45.txt")),
+			},
+			{
+				title: "path with linum",
+				value: filepath.Join(dir, "a:10:b.md"),
+				want:  parse.NewPathTarget(filepath.Join(dir, "a:10:b.md")),
+			},
+			{
+				title: "path with time and linum",
+				value: filepath.Join(dir, "logs/12:30:45.txt") + ":3",
+				want:  parse.NewTarget(filepath.Join(dir, "logs/12:30:45.txt"), 3),
+			},
+			{
+				title: "grep output with line in message",
+				value: filepath.Join(dir, "a.go") + ":5:// at 12:30",
+				want:  parse.NewTarget(filepath.Join(dir, "a.go"), 5),
+			},
+		} {
+			t.Run(tc.title, func(t *testing.T) {
+				got, err := parse.ReadTarget(tc.value)
+				if assert.Nil(t, err) {
+					assert.Equal(t, tc.want.String(), got.String())
+				}
+			})
+		}
+	})
+
 	for _, tc := range []struct {
 		title string
 		value string
@@ -33,6 +175,146 @@ func TestReadTarget(t *testing.T) {
 			value: "a:1",
 			want:  parse.NewTarget("a", 1),
 		},
+		{
+			title: "path and range",
+			value: "a:10-25",
+			want:  parse.NewRangeTarget("a", 10, 25),
+		},
+		{
+			title: "path and single line range",
+			value: "a:10-10",
+			want:  parse.NewRangeTarget("a", 10, 10),
+		},
+		{
+			title: "compiler output",
+			value: "a.go:10:5: undefined: x",
+			want:  parse.NewLineTarget("a.go", 10, -1, 5),
+		},
+		{
+			title: "column with trailing colon",
+			value: "a.go:10:5:",
+			want:  parse.NewLineTarget("a.go", 10, -1, 5),
+		},
+		{
+			title: "grep output",
+			value: "a.go:10:func main() {",
+			want:  parse.NewTarget("a.go", 10),
+		},
+		{
+			title: "message with colons",
+			value: "a.go:10: x: y: z",
+			want:  parse.NewTarget("a.go", 10),
+		},
+		{
+			title: "range and column",
+			value: "a.go:10-25:5",
+			want:  parse.NewLineTarget("a.go", 10, 25, 5),
+		},
+		{
+			title: "pattern",
+			value: "a.go:/func run/",
+			want:  parse.NewPatternTarget("a.go", "func run", ""),
+		},
+		{
+			title: "pattern range",
+			value: "a.go:/^func run/,/^}/",
+			want:  parse.NewPatternTarget("a.go", "^func run", "^}"),
+		},
+		{
+			title: "pattern with slash and colon",
+			value: `docs/a:b.md:/a\/b: c/`,
+			want:  parse.NewPatternTarget("docs/a:b.md", "a/b: c", ""),
+		},
+		{
+			title: "grep output like a pattern",
+			value: ".gitignore:1:/vendor/",
+			want:  parse.NewTarget(".gitignore", 1),
+		},
+		{
+			title: "pattern with escaped colon",
+			value: `dir/2024\:10:/x/`,
+			want:  parse.NewPatternTarget("dir/2024:10", "x", ""),
+		},
+		{
+			title: "not a pattern",
+			value: "a.go:/x",
+			want:  parse.NewPathTarget("a.go:/x"),
+		},
+		{
+			title: "path with colon",
+			value: "docs/a:b.md",
+			want:  parse.NewPathTarget("docs/a:b.md"),
+		},
+		{
+			title: "path with colon and linum",
+			value: "docs/a:b.md:10",
+			want:  parse.NewTarget("docs/a:b.md", 10),
+		},
+		{
+			title: "path with colon and number",
+			value: "dir/12:30:file.go:40",
+			want:  parse.NewTarget("dir/12:30:file.go", 40),
+		},
+		{
+			title: "path with colon and column",
+			value: "dir/12:30:file.go:40:5",
+			want:  parse.NewLineTarget("dir/12:30:file.go", 40, -1, 5),
+		},
+		{
+			title: "numbers following column",
+			value: "a.go:10:5:3",
+			want:  parse.NewLineTarget("a.go", 10, -1, 5),
+		},
+		{
+			title: "not a range",
+			value: "a:10-",
+			want:  parse.NewPathTarget("a:10-"),
+		},
+		{
+			title: "escaped colon",
+			value: `dir/2024\:10`,
+			want:  parse.NewPathTarget("dir/2024:10"),
+		},
+		{
+			title: "escaped colon and linum",
+			value: `dir/2024\:10:5`,
+			want:  parse.NewTarget("dir/2024:10", 5),
+		},
+		{
+			title: "quickfix",
+			value: "a.go|10 col 5| undefined: x",
+			want:  parse.NewLineTarget("a.go", 10, -1, 5),
+		},
+		{
+			title: "quickfix without column",
+			value: "a.go|10| func main() {",
+			want:  parse.NewTarget("a.go", 10),
+		},
+		{
+			title: "quickfix range",
+			value: "docs/a:b.md|10-12 col 5-8| x",
+			want:  parse.NewLineTarget("docs/a:b.md", 10, 12, 5),
+		},
+		{
+			title: "quickfix without line",
+			value: "a.go|| x",
+			want:  parse.NewPathTarget("a.go"),
+		},
+		{
+			title: "drive letter",
+			value: `C:\src\x.go`,
+			want:  parse.NewPathTarget(`C:\src\x.go`),
+		},
+		{
+			title: "drive letter and linum",
+			value: `C:\src\x.go:10`,
+			want:  parse.NewTarget(`C:\src\x.go`, 10),
+		},
+		{
+			title: "drive letter and compiler output",
+			value: `C:/src/x.go:10:5: undefined: x`,
+			want:  parse.NewLineTarget("C:/src/x.go", 10, -1, 5),
+		},
 	} {
 		t.Run(tc.title, func(t *testing.T) {
 			got, err := parse.ReadTarget(tc.value)
@@ -45,6 +327,27 @@ func TestReadTarget(t *testing.T) {
 			if wantHasLinum {
 				assert.Equal(t, wantLinum, gotLinum)
 			}
+
+			wantEndLinum, wantHasEndLinum := tc.want.EndLinum()
+			gotEndLinum, gotHasEndLinum := got.EndLinum()
+			assert.Equal(t, wantHasEndLinum, gotHasEndLinum)
+			if wantHasEndLinum {
+				assert.Equal(t, wantEndLinum, gotEndLinum)
+			}
+
+			wantColumn, wantHasColumn := tc.want.Column()
+			gotColumn, gotHasColumn := got.Column()
+			assert.Equal(t, wantHasColumn, gotHasColumn)
+			if wantHasColumn {
+				assert.Equal(t, wantColumn, gotColumn)
+			}
+
+			wantPattern, wantEndPattern, wantHasPattern := tc.want.Pattern()
+			gotPattern, gotEndPattern, gotHasPattern := got.Pattern()
+			assert.Equal(t, wantHasPattern, gotHasPattern)
+			assert.Equal(t, wantPattern, gotPattern)
+			assert.Equal(t, wantEndPattern, gotEndPattern)
+			assert.Equal(t, tc.want.String(), got.String())
 		})
 	}
 }
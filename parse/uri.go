@@ -0,0 +1,110 @@
+package parse
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// vscodeSchemes are the schemes of the editors opening vscode://file/PATH:LINUM:COLUMN.
+var vscodeSchemes = []string{"vscode", "vscode-insiders", "vscodium", "cursor"}
+
+// readURITarget parses the URIs of the files of the editors.
+// Returns false if the value is not the URI.
+func readURITarget(value string) (*Target, bool, error) {
+	scheme, _, ok := strings.Cut(value, "://")
+	if !ok {
+		return nil, false, nil
+	}
+	scheme = strings.ToLower(scheme)
+	switch {
+	case scheme == "file":
+		t, err := readFileURI(value)
+		return t, true, err
+	case scheme == "idea":
+		t, err := readIdeaURI(value)
+		return t, true, err
+	case isVSCodeScheme(scheme):
+		t, err := readVSCodeURI(value)
+		return t, true, err
+	default:
+		return nil, false, nil
+	}
+}
+
+func isVSCodeScheme(scheme string) bool {
+	for _, x := range vscodeSchemes {
+		if scheme == x {
+			return true
+		}
+	}
+	return false
+}
+
+// readFileURI parses file:///PATH.
+func readFileURI(value string) (*Target, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path == "" {
+		return nil, errors.New("no path")
+	}
+	return NewPathTarget(uriPath(u.Path)), nil
+}
+
+// readVSCodeURI parses vscode://file/PATH[:LINUM[:COLUMN]].
+func readVSCodeURI(value string) (*Target, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host != "file" || u.Path == "" {
+		return nil, errors.New("not a file")
+	}
+	return readTarget(uriPath(u.Path))
+}
+
+// readIdeaURI parses idea://open?file=PATH[&line=LINUM[&column=COLUMN]].
+func readIdeaURI(value string) (*Target, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	path := q.Get("file")
+	if path == "" {
+		return nil, errors.New("no file")
+	}
+	linum, err := queryInt(q, "line")
+	if err != nil {
+		return nil, err
+	}
+	column, err := queryInt(q, "column")
+	if err != nil {
+		return nil, err
+	}
+	return NewLineTarget(path, linum, -1, column), nil
+}
+
+// queryInt returns the number of the query, -1 if not exists.
+func queryInt(q url.Values, key string) (int, error) {
+	v := q.Get(key)
+	if v == "" {
+		return -1, nil
+	}
+	return strconv.Atoi(v)
+}
+
+// uriDriveRegexp matches the drive letter of Windows in the path of the URI like /C:/.
+var uriDriveRegexp = regexp.MustCompile(`^/[A-Za-z]:/`)
+
+// uriPath removes the slash before the drive letter of Windows.
+func uriPath(path string) string {
+	if uriDriveRegexp.MatchString(path) {
+		return path[1:]
+	}
+	return path
+}
@@ -0,0 +1,92 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/berquerant/gbrowse/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTargetURI(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for _, value := range []string{
+			"file://",
+			"vscode://extension/x",
+			"vscode://file/a.go:25-10",
+			"idea://open?line=10",
+			"idea://open?file=/a.go&line=x",
+		} {
+			_, err := parse.ReadTarget(value)
+			assert.NotNil(t, err, value)
+		}
+	})
+
+	for _, tc := range []struct {
+		title string
+		value string
+		want  *parse.Target
+	}{
+		{
+			title: "file",
+			value: "file:///abs/path/x.go",
+			want:  parse.NewPathTarget("/abs/path/x.go"),
+		},
+		{
+			title: "file localhost",
+			value: "file://localhost/abs/path/x.go",
+			want:  parse.NewPathTarget("/abs/path/x.go"),
+		},
+		{
+			title: "file percent encoded",
+			value: "file:///abs/my%20dir/x%3Ay.go",
+			want:  parse.NewPathTarget("/abs/my dir/x:y.go"),
+		},
+		{
+			title: "file windows",
+			value: "file:///C:/src/x.go",
+			want:  parse.NewPathTarget("C:/src/x.go"),
+		},
+		{
+			title: "vscode",
+			value: "vscode://file/abs/path/x.go",
+			want:  parse.NewPathTarget("/abs/path/x.go"),
+		},
+		{
+			title: "vscode line column",
+			value: "vscode://file/abs/path/x.go:10:5",
+			want:  parse.NewLineTarget("/abs/path/x.go", 10, -1, 5),
+		},
+		{
+			title: "vscode insiders percent encoded",
+			value: "vscode-insiders://file/abs/my%20dir/x.go:10",
+			want:  parse.NewTarget("/abs/my dir/x.go", 10),
+		},
+		{
+			title: "vscode windows",
+			value: "vscode://file/c:/src/x.go:10",
+			want:  parse.NewTarget("c:/src/x.go", 10),
+		},
+		{
+			title: "idea",
+			value: "idea://open?file=/abs/path/x.go&line=10",
+			want:  parse.NewTarget("/abs/path/x.go", 10),
+		},
+		{
+			title: "idea column percent encoded",
+			value: "idea://open?file=%2Fabs%2Fmy%20dir%2Fx.go&line=10&column=5",
+			want:  parse.NewLineTarget("/abs/my dir/x.go", 10, -1, 5),
+		},
+		{
+			title: "idea file only",
+			value: "idea://open?file=/abs/path/x.go",
+			want:  parse.NewPathTarget("/abs/path/x.go"),
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			got, err := parse.ReadTarget(tc.value)
+			if assert.Nil(t, err) {
+				assert.Equal(t, tc.want.String(), got.String())
+			}
+		})
+	}
+}
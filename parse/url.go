@@ -1,39 +1,186 @@
 package parse
 
-import "strings"
-
-func ReadRepoURL(value string) string {
-	replaceTuples := []struct {
-		from, to string
-	}{
-		{
-			from: ":",
-			to:   "/",
-		},
-		{
-			from: "git@",
-			to:   "https///",
-		},
-		{
-			from: "git///",
-			to:   "https///",
-		},
-		{
-			from: ".git",
-			to:   "",
-		},
-		{
-			from: "https///",
-			to:   "https://",
-		},
-		{
-			from: "ssh///https:",
-			to:   "https:",
-		},
-	}
-
-	for _, t := range replaceTuples {
-		value = strings.ReplaceAll(value, t.from, t.to)
-	}
-	return value
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/berquerant/gbrowse/ctxlog"
+)
+
+// RemoteURL is a parsed git remote url.
+//
+// Accepts the forms of git urls:
+//
+//	ssh://[user@]host.xz[:port]/path/to/repo.git/
+//	git://host.xz[:port]/path/to/repo.git/
+//	http[s]://[user[:password]@]host.xz[:port]/path/to/repo.git/
+//	ftp[s]://host.xz[:port]/path/to/repo.git/
+//	git+ssh://, ssh+git:// as ssh://
+//	[user@]host.xz:path/to/repo.git/ (scp-like syntax)
+//	/path/to/repo.git/, file:///path/to/repo.git/ (local)
+//	<transport>::<address>
+type RemoteURL struct {
+	// Original is the given remote url.
+	Original string
+	// Scheme is ssh for the scp-like syntax and file for the local path.
+	Scheme string
+	User   string
+	// Password is the password or the token in the http remote.
+	Password string
+	Host     string
+	Port     string
+	// Path is the segments of the path without the suffix .git.
+	Path []string
+}
+
+// String returns the original remote url without credentials.
+func (r *RemoteURL) String() string {
+	return ctxlog.Redact(r.Original)
+}
+
+// ErrNoWebURL means that the remote has no web ui like a local repository.
+var ErrNoWebURL = errors.New("no web url")
+
+// BaseURL returns the url of the web ui of the host.
+// http remotes keep the scheme and the port,
+// the others are https without the port because the port is not the one of the web ui.
+func (r *RemoteURL) BaseURL() (string, error) {
+	switch r.Scheme {
+	case "file":
+		return "", fmt.Errorf("%w: %s is local", ErrNoWebURL, r)
+	case "http", "https":
+		if r.Port != "" {
+			return r.Scheme + "://" + net.JoinHostPort(r.Host, r.Port), nil
+		}
+		return r.Scheme + "://" + r.hostname(), nil
+	default:
+		return "https://" + r.hostname(), nil
+	}
+}
+
+// hostname returns the host bracketed if it is an IPv6 address.
+func (r *RemoteURL) hostname() string {
+	if strings.Contains(r.Host, ":") {
+		return "[" + r.Host + "]"
+	}
+	return r.Host
+}
+
+// RepoPath returns the path of the repository on the host like owner/repo.
+// The segments of the path are not escaped.
+func (r *RemoteURL) RepoPath() string {
+	return strings.Join(r.Path, "/")
+}
+
+// WebURL returns the url of the web ui of the repository like https://host/owner/repo.
+// The segments of the path are escaped.
+func (r *RemoteURL) WebURL() (string, error) {
+	base, err := r.BaseURL()
+	if err != nil {
+		return "", err
+	}
+	xs := make([]string, len(r.Path))
+	for i, x := range r.Path {
+		xs[i] = url.PathEscape(x)
+	}
+	return base + "/" + strings.Join(xs, "/"), nil
+}
+
+var (
+	// transportRegexp matches <transport>::<address>.
+	transportRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*::(.+)$`)
+	// schemeRegexp matches <scheme>://.
+	schemeRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*://`)
+	// scpRegexp matches [user@]host:path, the host can be bracketed like [::1].
+	scpRegexp = regexp.MustCompile(`^(?:([^@/]+)@)?(\[[^\]]+\]|[^:/\[\]]+):(.*)$`)
+	// driveRegexp matches the drive letter of Windows.
+	driveRegexp = regexp.MustCompile(`^[A-Za-z]:[\\/]`)
+)
+
+// ReadRemoteURL parses the git remote url.
+func ReadRemoteURL(value string) (*RemoteURL, error) {
+	r, err := readRemoteURL(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote url %s: %w", ctxlog.Redact(value), err)
+	}
+	r.Original = value
+	return r, nil
+}
+
+func readRemoteURL(value string) (*RemoteURL, error) {
+	if value == "" {
+		return nil, errors.New("empty")
+	}
+	if m := transportRegexp.FindStringSubmatch(value); m != nil {
+		return readRemoteURL(m[1])
+	}
+	if schemeRegexp.MatchString(value) {
+		return readSchemeRemoteURL(value)
+	}
+	if !driveRegexp.MatchString(value) {
+		if m := scpRegexp.FindStringSubmatch(value); m != nil {
+			return &RemoteURL{
+				Scheme: "ssh",
+				User:   m[1],
+				Host:   strings.Trim(m[2], "[]"),
+				Path:   unescapePath(splitRepoPath(m[3])),
+			}, nil
+		}
+	}
+	return &RemoteURL{
+		Scheme: "file",
+		Path:   splitRepoPath(value),
+	}, nil
+}
+
+func readSchemeRemoteURL(value string) (*RemoteURL, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	r := &RemoteURL{
+		Scheme: strings.ToLower(u.Scheme),
+		Host:   u.Hostname(),
+		Port:   u.Port(),
+		Path:   splitRepoPath(u.Path),
+	}
+	switch r.Scheme {
+	case "git+ssh", "ssh+git":
+		r.Scheme = "ssh"
+	case "file":
+		return r, nil
+	}
+	if r.Host == "" {
+		return nil, errors.New("no host")
+	}
+	if u.User != nil {
+		r.User = u.User.Username()
+		r.Password, _ = u.User.Password()
+	}
+	return r, nil
+}
+
+// unescapePath decodes the percent-encoded segments like the path of url.Parse.
+// Keeps the segment if it is not a valid encoding.
+func unescapePath(segments []string) []string {
+	for i, x := range segments {
+		if s, err := url.PathUnescape(x); err == nil {
+			segments[i] = s
+		}
+	}
+	return segments
+}
+
+// splitRepoPath splits the path into the segments and removes the suffix .git.
+func splitRepoPath(path string) []string {
+	path = strings.Trim(strings.ReplaceAll(path, `\`, "/"), "/")
+	path = strings.TrimSuffix(path, ".git")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
 }
@@ -0,0 +1,244 @@
+package sshconfig
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Config is the ssh client config like ~/.ssh/config.
+//
+// Supports Host, HostName and Include.
+// Match blocks never match except Match all.
+type Config struct {
+	entries []*entry
+}
+
+type entry struct {
+	// conditions are the patterns of the Host lines enclosing the entry.
+	// The entry is in effect if the host matches all of them.
+	conditions [][]string
+	key        string
+	value      string
+}
+
+func (e *entry) matches(host string) bool {
+	for _, patterns := range e.conditions {
+		if !matchPatterns(patterns, host) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchPatterns reports whether the host matches any pattern and no negated pattern.
+func matchPatterns(patterns []string, host string) bool {
+	host = strings.ToLower(host)
+	var matched bool
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		negated := strings.HasPrefix(p, "!")
+		if ok, _ := path.Match(strings.TrimPrefix(p, "!"), host); !ok {
+			continue
+		}
+		if negated {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+// HostName returns the real host name of the host alias.
+// Returns the host if no HostName applies.
+func (c *Config) HostName(host string) string {
+	// the first obtained value is used, like ssh
+	for _, e := range c.entries {
+		if e.key == "hostname" && e.matches(host) {
+			return expandHostName(e.value, host)
+		}
+	}
+	return host
+}
+
+// expandHostName expands the tokens %h and %%.
+func expandHostName(value, host string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '%' && i+1 < len(value) {
+			switch value[i+1] {
+			case 'h':
+				b.WriteString(host)
+				i++
+				continue
+			case '%':
+				b.WriteByte('%')
+				i++
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// maxDepth is the limit of the nested Include.
+const maxDepth = 16
+
+// Load reads the config file.
+// Returns an empty config if the file does not exist.
+// Relative paths of Include are relative to the directory of the file.
+func Load(file string) (*Config, error) {
+	p := &parser{
+		dir: filepath.Dir(file),
+	}
+	if err := p.parseFile(file, nil, 0); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, err
+	}
+	return &Config{
+		entries: p.entries,
+	}, nil
+}
+
+type parser struct {
+	dir     string
+	entries []*entry
+}
+
+func (p *parser) parseFile(file string, conditions [][]string, depth int) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := p.parse(f, file, conditions, depth); err != nil {
+		return fmt.Errorf("failed to read ssh config %s: %w", file, err)
+	}
+	return nil
+}
+
+func (p *parser) parse(r io.Reader, file string, parent [][]string, depth int) error {
+	var (
+		conditions = parent
+		scanner    = bufio.NewScanner(r)
+		linum      int
+	)
+	for scanner.Scan() {
+		linum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, args, err := splitLine(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", linum, err)
+		}
+
+		switch key {
+		case "host":
+			conditions = appendCondition(parent, args)
+		case "match":
+			if len(args) == 1 && strings.EqualFold(args[0], "all") {
+				conditions = parent
+			} else {
+				// no host matches the empty patterns
+				conditions = appendCondition(parent, nil)
+			}
+		case "include":
+			if depth+1 >= maxDepth {
+				return fmt.Errorf("line %d: too deep include", linum)
+			}
+			for _, arg := range args {
+				files, err := filepath.Glob(p.includePath(arg))
+				if err != nil {
+					return fmt.Errorf("line %d: %w", linum, err)
+				}
+				for _, x := range files {
+					if err := p.parseFile(x, conditions, depth+1); err != nil {
+						return err
+					}
+				}
+			}
+		default:
+			if len(args) > 0 {
+				p.entries = append(p.entries, &entry{
+					conditions: conditions,
+					key:        key,
+					value:      args[0],
+				})
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func appendCondition(conditions [][]string, patterns []string) [][]string {
+	xs := make([][]string, len(conditions), len(conditions)+1)
+	copy(xs, conditions)
+	if patterns == nil {
+		patterns = []string{}
+	}
+	return append(xs, patterns)
+}
+
+func (p *parser) includePath(value string) string {
+	if x, ok := strings.CutPrefix(value, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, x)
+		}
+	}
+	if filepath.IsAbs(value) {
+		return value
+	}
+	return filepath.Join(p.dir, value)
+}
+
+// splitLine splits the line into the lowercased keyword and the arguments.
+// The keyword is separated by whitespaces or =, the arguments can be double quoted.
+func splitLine(line string) (string, []string, error) {
+	i := strings.IndexAny(line, " \t=")
+	if i < 0 {
+		return strings.ToLower(line), nil, nil
+	}
+	key := strings.ToLower(line[:i])
+	rest := strings.TrimLeft(line[i:], " \t")
+	rest = strings.TrimLeft(strings.TrimPrefix(rest, "="), " \t")
+
+	var (
+		args   []string
+		b      strings.Builder
+		quoted bool
+		inArg  bool
+	)
+	for _, c := range rest {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			inArg = true
+		case !quoted && (c == ' ' || c == '\t'):
+			if inArg {
+				args = append(args, b.String())
+				b.Reset()
+				inArg = false
+			}
+		default:
+			b.WriteRune(c)
+			inArg = true
+		}
+	}
+	if quoted {
+		return "", nil, errors.New("unterminated quote")
+	}
+	if inArg {
+		args = append(args, b.String())
+	}
+	return key, args, nil
+}
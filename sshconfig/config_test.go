@@ -0,0 +1,102 @@
+package sshconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/berquerant/gbrowse/sshconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig(t *testing.T) {
+	c, err := sshconfig.Load(filepath.Join("testdata", "config"))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	for _, tc := range []struct {
+		title string
+		host  string
+		want  string
+	}{
+		{
+			title: "alias",
+			host:  "gh-work",
+			want:  "github.com",
+		},
+		{
+			title: "case insensitive",
+			host:  "GH-WORK",
+			want:  "github.com",
+		},
+		{
+			title: "quoted and equal sign",
+			host:  "gl-work",
+			want:  "gitlab.example.com",
+		},
+		{
+			title: "include",
+			host:  "bb-work",
+			want:  "bitbucket.org",
+		},
+		{
+			title: "first obtained value wins",
+			host:  "gh-private",
+			want:  "private.example.com",
+		},
+		{
+			title: "pattern with token",
+			host:  "git.corp",
+			want:  "git.corp.example.com",
+		},
+		{
+			title: "negated pattern",
+			host:  "bastion.corp",
+			want:  "bastion.corp",
+		},
+		{
+			title: "no hostname",
+			host:  "github.com",
+			want:  "github.com",
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			assert.Equal(t, tc.want, c.HostName(tc.host))
+		})
+	}
+
+	t.Run("not exist", func(t *testing.T) {
+		c, err := sshconfig.Load(filepath.Join("testdata", "not-exist"))
+		if assert.Nil(t, err) {
+			assert.Equal(t, "gh-work", c.HostName("gh-work"))
+		}
+	})
+
+	t.Run("include in host", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "config")
+		if err := os.WriteFile(file, []byte("Host gh-*\n  Include included\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "included"), []byte("HostName github.com\nHost gl\n  HostName gitlab.com\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		c, err := sshconfig.Load(file)
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, "github.com", c.HostName("gh-work"))
+		assert.Equal(t, "other", c.HostName("other"))
+		assert.Equal(t, "gl", c.HostName("gl"))
+	})
+
+	t.Run("unterminated quote", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "config")
+		if err := os.WriteFile(file, []byte("Host \"gh\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		_, err := sshconfig.Load(file)
+		assert.NotNil(t, err)
+	})
+}
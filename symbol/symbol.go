@@ -0,0 +1,254 @@
+package symbol
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Target is a declaration of Go like ./urlx.Build or urlx/url.go#Repo.Build.
+type Target struct {
+	// Dir is the directory of the package, empty if File is set.
+	Dir string
+	// File is the Go file.
+	File string
+	// Name is the name of a function, a type, a variable or a constant,
+	// or a method like Type.Method.
+	Name string
+}
+
+func (t *Target) String() string {
+	if t.File != "" {
+		return t.File + "#" + t.Name
+	}
+	return t.Dir + "." + t.Name
+}
+
+// Read parses the symbol target, FILE.go#NAME or DIR.NAME.
+// FILE.go requires to be an existing file, DIR.NAME requires DIR to be an existing directory.
+// The value is not a symbol if it is an existing path.
+func Read(value string) (*Target, bool) {
+	if strings.Contains(value, "://") {
+		// uri
+		return nil, false
+	}
+	if _, err := os.Stat(value); err == nil {
+		return nil, false
+	}
+	if file, name, ok := strings.Cut(value, "#"); ok {
+		if !strings.HasSuffix(file, ".go") || !isName(name) {
+			return nil, false
+		}
+		if x, err := os.Stat(file); err != nil || !x.Mode().IsRegular() {
+			return nil, false
+		}
+		return &Target{
+			File: file,
+			Name: name,
+		}, true
+	}
+
+	i := strings.LastIndex(value, "/") + 1
+	j := strings.Index(value[i:], ".")
+	if j < 1 {
+		// no dots or a dot file like ./.env
+		return nil, false
+	}
+	dir, name := value[:i+j], value[i+j+1:]
+	if dir == "" || !isName(name) {
+		return nil, false
+	}
+	if x, err := os.Stat(dir); err != nil || !x.IsDir() {
+		return nil, false
+	}
+	return &Target{
+		Dir:  dir,
+		Name: name,
+	}, true
+}
+
+// isName returns true if the value is NAME or NAME.NAME.
+func isName(value string) bool {
+	xs := strings.Split(value, ".")
+	if len(xs) > 2 {
+		return false
+	}
+	for _, x := range xs {
+		if !token.IsIdentifier(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// Location is the lines of the declaration.
+type Location struct {
+	File     string
+	Linum    int
+	EndLinum int
+}
+
+var (
+	ErrNotFound  = errors.New("symbol not found")
+	ErrAmbiguous = errors.New("ambiguous symbol")
+)
+
+// Locate finds the declaration of the target.
+//
+// NAME matches the top-level declarations,
+// or the methods if no top-level declarations match.
+// TYPE.NAME matches the methods of the type.
+func (t *Target) Locate() (*Location, error) {
+	locs, err := t.locate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate %s: %w", t, err)
+	}
+	switch len(locs) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, t)
+	case 1:
+		return locs[0], nil
+	default:
+		xs := make([]string, len(locs))
+		for i, x := range locs {
+			xs[i] = fmt.Sprintf("%s:%d", x.File, x.Linum)
+		}
+		return nil, fmt.Errorf("%w: %s matches %s", ErrAmbiguous, t, strings.Join(xs, ", "))
+	}
+}
+
+func (t *Target) locate() ([]*Location, error) {
+	files, err := t.files()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fset     = token.NewFileSet()
+		decls    []*Location
+		methods  []*Location
+		recv, fn = splitName(t.Name)
+	)
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		newLocation := func(node ast.Node) *Location {
+			return &Location{
+				File:     file,
+				Linum:    fset.Position(node.Pos()).Line,
+				EndLinum: fset.Position(node.End()).Line,
+			}
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Name.Name != fn {
+					continue
+				}
+				switch {
+				case d.Recv == nil && recv == "":
+					decls = append(decls, newLocation(d))
+				case d.Recv != nil && (recv == "" || recv == receiverType(d.Recv)):
+					methods = append(methods, newLocation(d))
+				}
+			case *ast.GenDecl:
+				if recv != "" {
+					continue
+				}
+				for _, spec := range d.Specs {
+					if !hasName(spec, fn) {
+						continue
+					}
+					// the whole declaration if not grouped
+					var node ast.Node = d
+					if d.Lparen.IsValid() {
+						node = spec
+					}
+					decls = append(decls, newLocation(node))
+				}
+			}
+		}
+	}
+
+	if len(decls) > 0 {
+		return decls, nil
+	}
+	return methods, nil
+}
+
+// files returns the Go files of the package for the current build context, except tests.
+func (t *Target) files() ([]string, error) {
+	if t.File != "" {
+		return []string{t.File}, nil
+	}
+	entries, err := os.ReadDir(t.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(t.Dir, name); err != nil || !ok {
+			continue
+		}
+		files = append(files, filepath.Join(t.Dir, name))
+	}
+	return files, nil
+}
+
+// splitName splits TYPE.NAME into TYPE and NAME.
+func splitName(name string) (string, string) {
+	if recv, fn, ok := strings.Cut(name, "."); ok {
+		return recv, fn
+	}
+	return "", name
+}
+
+// receiverType returns the name of the type of the receiver like T of *T[K].
+func receiverType(recv *ast.FieldList) string {
+	if len(recv.List) == 0 {
+		return ""
+	}
+	expr := recv.List[0].Type
+	for {
+		switch x := expr.(type) {
+		case *ast.StarExpr:
+			expr = x.X
+		case *ast.ParenExpr:
+			expr = x.X
+		case *ast.IndexExpr:
+			expr = x.X
+		case *ast.IndexListExpr:
+			expr = x.X
+		case *ast.Ident:
+			return x.Name
+		default:
+			return ""
+		}
+	}
+}
+
+func hasName(spec ast.Spec, name string) bool {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return s.Name.Name == name
+	case *ast.ValueSpec:
+		for _, x := range s.Names {
+			if x.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
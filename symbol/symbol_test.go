@@ -0,0 +1,177 @@
+package symbol_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/berquerant/gbrowse/symbol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	for _, tc := range []struct {
+		title string
+		value string
+		want  *symbol.Target
+	}{
+		{
+			title: "file",
+			value: "testdata/pkg/a.go#Build",
+			want: &symbol.Target{
+				File: "testdata/pkg/a.go",
+				Name: "Build",
+			},
+		},
+		{
+			title: "file method",
+			value: "testdata/pkg/a.go#Repo.build",
+			want: &symbol.Target{
+				File: "testdata/pkg/a.go",
+				Name: "Repo.build",
+			},
+		},
+		{
+			title: "package",
+			value: "./testdata/pkg.Build",
+			want: &symbol.Target{
+				Dir:  "./testdata/pkg",
+				Name: "Build",
+			},
+		},
+		{
+			title: "package method",
+			value: "testdata/pkg.Repo.Build",
+			want: &symbol.Target{
+				Dir:  "testdata/pkg",
+				Name: "Repo.Build",
+			},
+		},
+		{
+			title: "existing file",
+			value: "testdata/pkg/a.go",
+		},
+		{
+			title: "not go file",
+			value: "README.md#Build",
+		},
+		{
+			title: "missing file",
+			value: "testdata/pkg/none.go#Build",
+		},
+		{
+			title: "grep output",
+			value: "testdata/pkg/a.go:1:see a.go#Build",
+		},
+		{
+			title: "dot file",
+			value: "./.env",
+		},
+		{
+			title: "uri",
+			value: "file:///testdata/pkg/a.go#Build",
+		},
+		{
+			title: "not a directory",
+			value: "testdata/none.Build",
+		},
+		{
+			title: "not a name",
+			value: "testdata/pkg.a:10",
+		},
+		{
+			title: "too many dots",
+			value: "testdata/pkg.A.B.C",
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			got, ok := symbol.Read(tc.value)
+			if tc.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			if assert.True(t, ok) {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	var (
+		a = filepath.Join("testdata", "pkg", "a.go")
+		b = filepath.Join("testdata", "pkg", "b.go")
+	)
+	for _, tc := range []struct {
+		title  string
+		target *symbol.Target
+		want   *symbol.Location
+		err    error
+	}{
+		{
+			title:  "function",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "Build"},
+			want:   &symbol.Location{File: a, Linum: 4, EndLinum: 6},
+		},
+		{
+			title:  "method",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "Repo.Build"},
+			want:   &symbol.Location{File: a, Linum: 12, EndLinum: 14},
+		},
+		{
+			title:  "method without type",
+			target: &symbol.Target{File: a, Name: "build"},
+			want:   &symbol.Location{File: a, Linum: 16, EndLinum: 18},
+		},
+		{
+			title:  "type",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "Repo"},
+			want:   &symbol.Location{File: a, Linum: 8, EndLinum: 10},
+		},
+		{
+			title:  "grouped const",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "B"},
+			want:   &symbol.Location{File: a, Linum: 22, EndLinum: 22},
+		},
+		{
+			title:  "var",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "Single"},
+			want:   &symbol.Location{File: a, Linum: 25, EndLinum: 25},
+		},
+		{
+			title:  "grouped generic type",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "Pair"},
+			want:   &symbol.Location{File: b, Linum: 4, EndLinum: 7},
+		},
+		{
+			title:  "generic method",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "Pair.String"},
+			want:   &symbol.Location{File: b, Linum: 11, EndLinum: 13},
+		},
+		{
+			title:  "ambiguous method",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "String"},
+			err:    symbol.ErrAmbiguous,
+		},
+		{
+			title:  "not found",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "None"},
+			err:    symbol.ErrNotFound,
+		},
+		{
+			title:  "method of other type",
+			target: &symbol.Target{Dir: "testdata/pkg", Name: "Other.Build"},
+			err:    symbol.ErrNotFound,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			got, err := tc.target.Locate()
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			if assert.Nil(t, err) {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package pkg
+
+// Build is a function.
+func Build() string {
+	return "build"
+}
+
+type Repo struct {
+	name string
+}
+
+func (r *Repo) Build() string {
+	return r.build()
+}
+
+func (r *Repo) build() string {
+	return r.name
+}
+
+const (
+	A = 1
+	B = 2
+)
+
+var Single = 1
@@ -0,0 +1,17 @@
+package pkg
+
+type (
+	Pair[K comparable, V any] struct {
+		Key   K
+		Value V
+	}
+	Other struct{}
+)
+
+func (p Pair[K, V]) String() string {
+	return "pair"
+}
+
+func (Other) String() string {
+	return "other"
+}
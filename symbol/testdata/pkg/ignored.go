@@ -0,0 +1,7 @@
+//go:build ignore
+
+package pkg
+
+func Build() string {
+	return "ignored"
+}
@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// frameRegexp matches the frame of the stack trace of Go like \t/path/to/file.go:123 +0x1a.
+var frameRegexp = regexp.MustCompile(`^\t(.+\.go):([0-9]+)( \+0x[0-9a-f]+)?$`)
+
+// Frame is the position of the frame of the stack trace.
+type Frame struct {
+	Path  string
+	Linum int
+}
+
+// ParseFrame parses the line of the file of the frame.
+func ParseFrame(line string) (*Frame, bool) {
+	m := frameRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return nil, false
+	}
+	linum, err := strconv.Atoi(m[2])
+	if err != nil {
+		return nil, false
+	}
+	return &Frame{
+		Path:  m[1],
+		Linum: linum,
+	}, true
+}
+
+// Annotate copies the stack trace from r to w, appending the url returned by link to the frames.
+// The frame is unchanged if link returns empty.
+func Annotate(r io.Reader, w io.Writer, link func(*Frame) string) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if _, err := io.WriteString(w, annotate(line, link)); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func annotate(line string, link func(*Frame) string) string {
+	body := strings.TrimRight(line, "\r\n")
+	frame, ok := ParseFrame(body)
+	if !ok {
+		return line
+	}
+	u := link(frame)
+	if u == "" {
+		return line
+	}
+	return body + " " + u + line[len(body):]
+}
@@ -0,0 +1,107 @@
+package trace_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/berquerant/gbrowse/trace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFrame(t *testing.T) {
+	for _, tc := range []struct {
+		title string
+		line  string
+		want  *trace.Frame
+	}{
+		{
+			title: "frame",
+			line:  "\t/src/repo/main.go:123 +0x1a",
+			want: &trace.Frame{
+				Path:  "/src/repo/main.go",
+				Linum: 123,
+			},
+		},
+		{
+			title: "frame without pc",
+			line:  "\t/src/repo/main.go:123",
+			want: &trace.Frame{
+				Path:  "/src/repo/main.go",
+				Linum: 123,
+			},
+		},
+		{
+			title: "windows",
+			line:  "\tC:/src/repo/main.go:123 +0x1a",
+			want: &trace.Frame{
+				Path:  "C:/src/repo/main.go",
+				Linum: 123,
+			},
+		},
+		{
+			title: "function",
+			line:  "main.main()",
+		},
+		{
+			title: "goroutine",
+			line:  "goroutine 1 [running]:",
+		},
+		{
+			title: "test log",
+			line:  "    main_test.go:12: failed",
+		},
+		{
+			title: "not go",
+			line:  "\t/src/repo/main.s:123",
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			got, ok := trace.ParseFrame(tc.line)
+			if tc.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			if assert.True(t, ok) {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	input := strings.Join([]string{
+		"panic: boom",
+		"",
+		"goroutine 1 [running]:",
+		"main.run(...)",
+		"\t/src/repo/main.go:10 +0x1a",
+		"fmt.Println(...)",
+		"\t/usr/local/go/src/fmt/print.go:314 +0x25",
+		"exit status 2\r",
+		"\t/src/repo/sub/x.go:20",
+	}, "\n")
+	want := strings.Join([]string{
+		"panic: boom",
+		"",
+		"goroutine 1 [running]:",
+		"main.run(...)",
+		"\t/src/repo/main.go:10 +0x1a https://example.com/main.go#L10",
+		"fmt.Println(...)",
+		"\t/usr/local/go/src/fmt/print.go:314 +0x25",
+		"exit status 2\r",
+		"\t/src/repo/sub/x.go:20 https://example.com/sub/x.go#L20",
+	}, "\n")
+
+	var b bytes.Buffer
+	err := trace.Annotate(strings.NewReader(input), &b, func(f *trace.Frame) string {
+		x, ok := strings.CutPrefix(f.Path, "/src/repo/")
+		if !ok {
+			return ""
+		}
+		return fmt.Sprintf("https://example.com/%s#L%d", x, f.Linum)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, want, b.String())
+}
@@ -0,0 +1,97 @@
+package urlx
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// azureForge generates urls of Azure DevOps Repos
+// like https://dev.azure.com/org/project/_git/repo?path=/path&version=GCref&line=10&lineEnd=10&lineStartColumn=1.
+//
+// The version is prefixed by GC for a commit, GB for a branch and GT for a tag.
+type azureForge struct{}
+
+func (azureForge) Name() string { return "azure" }
+
+func (azureForge) URL(loc *Location) (string, error) {
+	org, project, repo, err := azureRepo(loc.Host, loc.Repo)
+	if err != nil {
+		return "", err
+	}
+	query := []string{
+		"path=" + azureQueryEscape("/"+loc.Path),
+		"version=" + azureVersionPrefix(loc.RefType) + azureQueryEscape(loc.Ref),
+	}
+	if loc.HasLine() {
+		end := loc.Linum
+		if loc.HasRange() {
+			end = loc.EndLinum
+		}
+		column := 1
+		if loc.HasColumn() {
+			column = loc.Column
+		}
+		query = append(query,
+			fmt.Sprintf("line=%d", loc.Linum),
+			fmt.Sprintf("lineEnd=%d", end),
+			fmt.Sprintf("lineStartColumn=%d", column),
+		)
+	}
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_git/%s?%s",
+		url.PathEscape(org), url.PathEscape(project), url.PathEscape(repo), strings.Join(query, "&"),
+	), nil
+}
+
+func azureVersionPrefix(refType RefType) string {
+	switch refType {
+	case RefBranch:
+		return "GB"
+	case RefTag:
+		return "GT"
+	default:
+		return "GC"
+	}
+}
+
+func isAzureHost(host string) bool {
+	return host == "dev.azure.com" || host == "ssh.dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com")
+}
+
+// azureRepo extracts the organization, the project and the repository from the remote.
+//
+// Accepts the remotes:
+//
+//	git@ssh.dev.azure.com:v3/org/project/repo
+//	https://org@dev.azure.com/org/project/_git/repo
+//	https://org.visualstudio.com/project/_git/repo
+func azureRepo(host, repo string) (string, string, string, error) {
+	invalid := func() (string, string, string, error) {
+		return "", "", "", fmt.Errorf("invalid azure devops repository %s/%s", host, repo)
+	}
+
+	if xs, ok := strings.CutPrefix(repo, "v3/"); ok {
+		ys := strings.Split(xs, "/")
+		if len(ys) != 3 {
+			return invalid()
+		}
+		return ys[0], ys[1], ys[2], nil
+	}
+
+	xs := strings.Split(repo, "/")
+	if org, ok := strings.CutSuffix(host, ".visualstudio.com"); ok {
+		xs = append([]string{org}, xs...)
+	}
+	if len(xs) == 5 && xs[1] == "DefaultCollection" {
+		xs = append(xs[:1], xs[2:]...)
+	}
+	if len(xs) != 4 || xs[2] != "_git" {
+		return invalid()
+	}
+	return xs[0], xs[1], xs[3], nil
+}
+
+// azureQueryEscape escapes the query value keeping slashes to be readable.
+func azureQueryEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "%2F", "/")
+}
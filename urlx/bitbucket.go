@@ -0,0 +1,70 @@
+package urlx
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// bitbucketCloudForge generates urls like https://bitbucket.org/owner/repo/src/ref/path#lines-10.
+type bitbucketCloudForge struct{}
+
+func (bitbucketCloudForge) Name() string { return "bitbucket" }
+
+func (bitbucketCloudForge) URL(loc *Location) (string, error) {
+	var fragment string
+	// bitbucket has no anchor of the column
+	if loc.HasLine() {
+		fragment = fmt.Sprintf("#lines-%d", loc.Linum)
+		if loc.HasRange() {
+			fragment += fmt.Sprintf(":%d", loc.EndLinum)
+		}
+	}
+	return fmt.Sprintf("%s/src/%s/%s%s",
+		loc.RepoURL, loc.Ref, loc.Path, fragment,
+	), nil
+}
+
+// bitbucketServerForge generates urls of Bitbucket Server and Data Center
+// like https://host/projects/KEY/repos/repo/browse/path?at=ref#10.
+type bitbucketServerForge struct{}
+
+func (bitbucketServerForge) Name() string { return "bitbucket-server" }
+
+func (bitbucketServerForge) URL(loc *Location) (string, error) {
+	repoPath, err := bitbucketServerRepoPath(loc.Repo)
+	if err != nil {
+		return "", err
+	}
+	var path string
+	if loc.Path != "" {
+		path = "/" + loc.Path
+	}
+	var fragment string
+	// bitbucket server has no anchor of the column
+	if loc.HasLine() {
+		fragment = fmt.Sprintf("#%d", loc.Linum)
+		if loc.HasRange() {
+			fragment += fmt.Sprintf("-%d", loc.EndLinum)
+		}
+	}
+	return fmt.Sprintf("%s/%s/browse%s?at=%s%s",
+		loc.BaseURL, repoPath, path, url.QueryEscape(loc.Ref), fragment,
+	), nil
+}
+
+// bitbucketServerRepoPath converts the repository path of the remote into the path of the web ui.
+//
+// The ssh remote ssh://git@host:7999/key/repo.git and the http remote https://host/scm/key/repo.git
+// are converted into projects/KEY/repos/repo.
+// The personal repository ~user/repo is converted into users/user/repos/repo.
+func bitbucketServerRepoPath(repo string) (string, error) {
+	xs := strings.Split(strings.TrimPrefix(repo, "scm/"), "/")
+	if len(xs) != 2 {
+		return "", fmt.Errorf("invalid bitbucket server repository %s", repo)
+	}
+	if user, ok := strings.CutPrefix(xs[0], "~"); ok {
+		return fmt.Sprintf("users/%s/repos/%s", user, xs[1]), nil
+	}
+	return fmt.Sprintf("projects/%s/repos/%s", strings.ToUpper(xs[0]), xs[1]), nil
+}
@@ -0,0 +1,22 @@
+package urlx
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// cgitForge generates urls of cgit like https://host/repo/tree/path?id=ref#n10.
+type cgitForge struct{}
+
+func (cgitForge) Name() string { return "cgit" }
+
+func (cgitForge) URL(loc *Location) (string, error) {
+	var fragment string
+	// cgit has no anchor of the range of lines
+	if loc.HasLine() {
+		fragment = fmt.Sprintf("#n%d", loc.Linum)
+	}
+	return fmt.Sprintf("%s/tree/%s?id=%s%s",
+		loc.RepoURL, loc.Path, url.QueryEscape(loc.Ref), fragment,
+	), nil
+}
@@ -0,0 +1,123 @@
+// Code generated by "goconfig -field ConfigFile string|Ref string|Unpushed string|Remote string|Push bool|SSHConfig string -option -output config_generated.go"; DO NOT EDIT.
+
+package urlx
+
+type ConfigItem[T any] struct {
+	modified     bool
+	value        T
+	defaultValue T
+}
+
+func (s *ConfigItem[T]) Set(value T) {
+	s.modified = true
+	s.value = value
+}
+func (s *ConfigItem[T]) Get() T {
+	if s.modified {
+		return s.value
+	}
+	return s.defaultValue
+}
+func (s *ConfigItem[T]) Default() T {
+	return s.defaultValue
+}
+func (s *ConfigItem[T]) IsModified() bool {
+	return s.modified
+}
+func NewConfigItem[T any](defaultValue T) *ConfigItem[T] {
+	return &ConfigItem[T]{
+		defaultValue: defaultValue,
+	}
+}
+
+type Config struct {
+	ConfigFile *ConfigItem[string]
+	Ref        *ConfigItem[string]
+	Unpushed   *ConfigItem[string]
+	Remote     *ConfigItem[string]
+	Push       *ConfigItem[bool]
+	SSHConfig  *ConfigItem[string]
+}
+type ConfigBuilder struct {
+	configFile string
+	ref        string
+	unpushed   string
+	remote     string
+	push       bool
+	sSHConfig  string
+}
+
+func (s *ConfigBuilder) ConfigFile(v string) *ConfigBuilder {
+	s.configFile = v
+	return s
+}
+func (s *ConfigBuilder) Ref(v string) *ConfigBuilder {
+	s.ref = v
+	return s
+}
+func (s *ConfigBuilder) Unpushed(v string) *ConfigBuilder {
+	s.unpushed = v
+	return s
+}
+func (s *ConfigBuilder) Remote(v string) *ConfigBuilder {
+	s.remote = v
+	return s
+}
+func (s *ConfigBuilder) Push(v bool) *ConfigBuilder {
+	s.push = v
+	return s
+}
+func (s *ConfigBuilder) SSHConfig(v string) *ConfigBuilder {
+	s.sSHConfig = v
+	return s
+}
+func (s *ConfigBuilder) Build() *Config {
+	return &Config{
+		ConfigFile: NewConfigItem(s.configFile),
+		Ref:        NewConfigItem(s.ref),
+		Unpushed:   NewConfigItem(s.unpushed),
+		Remote:     NewConfigItem(s.remote),
+		Push:       NewConfigItem(s.push),
+		SSHConfig:  NewConfigItem(s.sSHConfig),
+	}
+}
+
+func NewConfigBuilder() *ConfigBuilder { return &ConfigBuilder{} }
+func (s *Config) Apply(opt ...ConfigOption) {
+	for _, x := range opt {
+		x(s)
+	}
+}
+
+type ConfigOption func(*Config)
+
+func WithConfigFile(v string) ConfigOption {
+	return func(c *Config) {
+		c.ConfigFile.Set(v)
+	}
+}
+func WithRef(v string) ConfigOption {
+	return func(c *Config) {
+		c.Ref.Set(v)
+	}
+}
+func WithUnpushed(v string) ConfigOption {
+	return func(c *Config) {
+		c.Unpushed.Set(v)
+	}
+}
+func WithRemote(v string) ConfigOption {
+	return func(c *Config) {
+		c.Remote.Set(v)
+	}
+}
+func WithPush(v bool) ConfigOption {
+	return func(c *Config) {
+		c.Push.Set(v)
+	}
+}
+func WithSSHConfig(v string) ConfigOption {
+	return func(c *Config) {
+		c.SSHConfig.Set(v)
+	}
+}
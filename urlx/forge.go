@@ -0,0 +1,173 @@
+package urlx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/berquerant/gbrowse/parse"
+)
+
+// Location is a position in the repository to be opened.
+type Location struct {
+	// RepoURL is the url of the repository, e.g. https://github.com/berquerant/gbrowse.
+	RepoURL string
+	// BaseURL is the url of the host, e.g. https://github.com.
+	BaseURL string
+	// Host is the host of the repository, e.g. github.com.
+	Host string
+	// Repo is the path of the repository on the host, e.g. berquerant/gbrowse.
+	Repo string
+	// Ref is the commit, branch or tag to be opened.
+	Ref string
+	// RefType is the kind of Ref.
+	RefType RefType
+	// Path is the path from the root of the repository.
+	Path string
+	// IsDir is true if Path is a directory.
+	IsDir bool
+	// Linum is the line number, 0 means no line.
+	Linum int
+	// EndLinum is the last line number of the range, 0 means no range.
+	EndLinum int
+	// Column is the column number of the line, 0 means no column.
+	Column int
+}
+
+// RefType is the kind of the ref.
+type RefType string
+
+const (
+	RefCommit RefType = "commit"
+	RefBranch RefType = "branch"
+	RefTag    RefType = "tag"
+)
+
+func newLocation(remote *parse.RemoteURL) (*Location, error) {
+	repoURL, err := remote.WebURL()
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := remote.BaseURL()
+	if err != nil {
+		return nil, err
+	}
+	return &Location{
+		RepoURL: repoURL,
+		BaseURL: baseURL,
+		Host:    remote.Host,
+		Repo:    remote.RepoPath(),
+	}, nil
+}
+
+// HasLine returns true if the location points to a line.
+func (loc *Location) HasLine() bool {
+	return loc.Linum > 0
+}
+
+// HasColumn returns true if the location points to a column of the line.
+func (loc *Location) HasColumn() bool {
+	return loc.HasLine() && loc.Column > 0
+}
+
+// HasRange returns true if the location points to the range of lines.
+func (loc *Location) HasRange() bool {
+	return loc.HasLine() && loc.EndLinum > loc.Linum
+}
+
+// Forge generates urls of a git hosting service.
+type Forge interface {
+	// Name returns the name of the forge.
+	Name() string
+	// URL returns the url of the location.
+	URL(loc *Location) (string, error)
+}
+
+var forges = []Forge{
+	&githubForge{},
+	&gitlabForge{},
+	&bitbucketCloudForge{},
+	&bitbucketServerForge{},
+	&azureForge{},
+	&giteaForge{},
+	&gitilesForge{},
+	&gitilesForge{plugin: true},
+	&sourcehutForge{},
+	&cgitForge{},
+}
+
+// settingForge is a forge configured by the host settings.
+type settingForge interface {
+	Forge
+	withSettings(host string, settings hostSettings) Forge
+}
+
+// forgeAliases maps the other names to the forge names.
+var forgeAliases = map[string]string{
+	"forgejo":  "gitea",
+	"codeberg": "gitea",
+}
+
+// LookupForge finds the forge by name.
+func LookupForge(name string) (Forge, bool) {
+	name = strings.ToLower(name)
+	if x, ok := forgeAliases[name]; ok {
+		name = x
+	}
+	for _, f := range forges {
+		if f.Name() == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// selectForge returns the forge set by the forge setting of the host if exists,
+// otherwise guesses from the host of the web ui by DetectForge.
+// The templates of the host settings take precedence over the forge.
+func selectForge(host, webHost string, settings hostSettings) (Forge, error) {
+	f := DetectForge(webHost)
+	if name, ok := settings.get(host, "forge"); ok {
+		if f, ok = LookupForge(name); !ok {
+			return nil, fmt.Errorf("unknown forge %s for %s", name, host)
+		}
+	}
+	if x, ok := f.(settingForge); ok {
+		f = x.withSettings(host, settings)
+	}
+	t, ok, err := newTemplateForge(f, host, settings)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return t, nil
+	}
+	return f, nil
+}
+
+// DetectForge guesses the forge from the host name.
+// Defaults to GitHub.
+func DetectForge(host string) Forge {
+	host = strings.ToLower(host)
+	switch {
+	case strings.Contains(host, "gitlab"):
+		return &gitlabForge{}
+	case host == "bitbucket.org":
+		return &bitbucketCloudForge{}
+	case strings.Contains(host, "bitbucket"), strings.Contains(host, "stash"):
+		return &bitbucketServerForge{}
+	case isAzureHost(host):
+		return &azureForge{}
+	case isGiteaHost(host):
+		return &giteaForge{}
+	case strings.HasSuffix(host, ".googlesource.com"):
+		return &gitilesForge{}
+	case strings.Contains(host, "gerrit"):
+		return &gitilesForge{plugin: true}
+	case host == "git.sr.ht":
+		return &sourcehutForge{}
+	case host == "git.kernel.org", strings.Contains(host, "cgit"):
+		return &cgitForge{}
+	default:
+		return &githubForge{}
+	}
+}
@@ -0,0 +1,399 @@
+package urlx_test
+
+import (
+	"testing"
+
+	"github.com/berquerant/gbrowse/urlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForge(t *testing.T) {
+	for _, tc := range []struct {
+		title string
+		host  string
+		loc   *urlx.Location
+		want  string
+	}{
+		{
+			title: "github file",
+			host:  "github.com",
+			loc: &urlx.Location{
+				RepoURL: "https://github.com/owner/repo",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+			},
+			want: "https://github.com/owner/repo/blob/sha/dir/file.go",
+		},
+		{
+			title: "github line",
+			host:  "github.com",
+			loc: &urlx.Location{
+				RepoURL: "https://github.com/owner/repo",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+				Linum:   10,
+			},
+			want: "https://github.com/owner/repo/blob/sha/dir/file.go#L10",
+		},
+		{
+			title: "github range",
+			host:  "github.com",
+			loc: &urlx.Location{
+				RepoURL:  "https://github.com/owner/repo",
+				Ref:      "sha",
+				Path:     "dir/file.go",
+				Linum:    10,
+				EndLinum: 25,
+			},
+			want: "https://github.com/owner/repo/blob/sha/dir/file.go#L10-L25",
+		},
+		{
+			title: "gitlab file",
+			host:  "gitlab.com",
+			loc: &urlx.Location{
+				RepoURL: "https://gitlab.com/foo/group/repo",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+			},
+			want: "https://gitlab.com/foo/group/repo/-/blob/sha/dir/file.go",
+		},
+		{
+			title: "gitlab line",
+			host:  "gitlab.example.com",
+			loc: &urlx.Location{
+				RepoURL: "https://gitlab.example.com/foo/group/repo",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+				Linum:   10,
+			},
+			want: "https://gitlab.example.com/foo/group/repo/-/blob/sha/dir/file.go#L10",
+		},
+		{
+			title: "gitlab range",
+			host:  "gitlab.com",
+			loc: &urlx.Location{
+				RepoURL:  "https://gitlab.com/foo/group/repo",
+				Ref:      "sha",
+				Path:     "dir/file.go",
+				Linum:    10,
+				EndLinum: 25,
+			},
+			want: "https://gitlab.com/foo/group/repo/-/blob/sha/dir/file.go#L10-25",
+		},
+		{
+			title: "gitlab dir",
+			host:  "gitlab.com",
+			loc: &urlx.Location{
+				RepoURL: "https://gitlab.com/foo/group/repo",
+				Ref:     "sha",
+				Path:    "dir",
+				IsDir:   true,
+			},
+			want: "https://gitlab.com/foo/group/repo/-/tree/sha/dir",
+		},
+		{
+			title: "bitbucket cloud line",
+			host:  "bitbucket.org",
+			loc: &urlx.Location{
+				RepoURL: "https://bitbucket.org/owner/repo",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+				Linum:   10,
+			},
+			want: "https://bitbucket.org/owner/repo/src/sha/dir/file.go#lines-10",
+		},
+		{
+			title: "bitbucket cloud range",
+			host:  "bitbucket.org",
+			loc: &urlx.Location{
+				RepoURL:  "https://bitbucket.org/owner/repo",
+				Ref:      "sha",
+				Path:     "dir/file.go",
+				Linum:    10,
+				EndLinum: 25,
+			},
+			want: "https://bitbucket.org/owner/repo/src/sha/dir/file.go#lines-10:25",
+		},
+		{
+			title: "bitbucket cloud column",
+			host:  "bitbucket.org",
+			loc: &urlx.Location{
+				RepoURL: "https://bitbucket.org/owner/repo",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+				Linum:   10,
+				Column:  5,
+			},
+			want: "https://bitbucket.org/owner/repo/src/sha/dir/file.go#lines-10",
+		},
+		{
+			title: "bitbucket server ssh line",
+			host:  "bitbucket.example.com",
+			loc: &urlx.Location{
+				BaseURL: "https://bitbucket.example.com",
+				Repo:    "key/repo",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+				Linum:   10,
+			},
+			want: "https://bitbucket.example.com/projects/KEY/repos/repo/browse/dir/file.go?at=sha#10",
+		},
+		{
+			title: "bitbucket server range",
+			host:  "bitbucket.example.com",
+			loc: &urlx.Location{
+				BaseURL:  "https://bitbucket.example.com",
+				Repo:     "key/repo",
+				Ref:      "sha",
+				Path:     "dir/file.go",
+				Linum:    10,
+				EndLinum: 25,
+			},
+			want: "https://bitbucket.example.com/projects/KEY/repos/repo/browse/dir/file.go?at=sha#10-25",
+		},
+		{
+			title: "bitbucket server http root",
+			host:  "stash.example.com",
+			loc: &urlx.Location{
+				BaseURL: "https://stash.example.com",
+				Repo:    "scm/key/repo",
+				Ref:     "sha",
+				IsDir:   true,
+			},
+			want: "https://stash.example.com/projects/KEY/repos/repo/browse?at=sha",
+		},
+		{
+			title: "bitbucket server personal",
+			host:  "bitbucket.example.com",
+			loc: &urlx.Location{
+				BaseURL: "https://bitbucket.example.com",
+				Repo:    "~user/repo",
+				Ref:     "sha",
+				Path:    "file.go",
+			},
+			want: "https://bitbucket.example.com/users/user/repos/repo/browse/file.go?at=sha",
+		},
+		{
+			title: "azure ssh line",
+			host:  "ssh.dev.azure.com",
+			loc: &urlx.Location{
+				Host:  "ssh.dev.azure.com",
+				Repo:  "v3/org/project/repo",
+				Ref:   "sha",
+				Path:  "dir/file.go",
+				Linum: 10,
+			},
+			want: "https://dev.azure.com/org/project/_git/repo?path=/dir/file.go&version=GCsha&line=10&lineEnd=10&lineStartColumn=1",
+		},
+		{
+			title: "azure ssh with space",
+			host:  "ssh.dev.azure.com",
+			loc: &urlx.Location{
+				Host:  "ssh.dev.azure.com",
+				Repo:  "v3/org/My Project/repo",
+				Ref:   "sha",
+				Path:  "dir/file.go",
+				Linum: 10,
+			},
+			want: "https://dev.azure.com/org/My%20Project/_git/repo?path=/dir/file.go&version=GCsha&line=10&lineEnd=10&lineStartColumn=1",
+		},
+		{
+			title: "azure range",
+			host:  "dev.azure.com",
+			loc: &urlx.Location{
+				Host:     "dev.azure.com",
+				Repo:     "org/project/_git/repo",
+				Ref:      "sha",
+				Path:     "dir/file.go",
+				Linum:    10,
+				EndLinum: 25,
+			},
+			want: "https://dev.azure.com/org/project/_git/repo?path=/dir/file.go&version=GCsha&line=10&lineEnd=25&lineStartColumn=1",
+		},
+		{
+			title: "azure column",
+			host:  "dev.azure.com",
+			loc: &urlx.Location{
+				Host:   "dev.azure.com",
+				Repo:   "org/project/_git/repo",
+				Ref:    "sha",
+				Path:   "dir/file.go",
+				Linum:  10,
+				Column: 5,
+			},
+			want: "https://dev.azure.com/org/project/_git/repo?path=/dir/file.go&version=GCsha&line=10&lineEnd=10&lineStartColumn=5",
+		},
+		{
+			title: "azure https root",
+			host:  "dev.azure.com",
+			loc: &urlx.Location{
+				Host:  "dev.azure.com",
+				Repo:  "org/project/_git/repo",
+				Ref:   "sha",
+				IsDir: true,
+			},
+			want: "https://dev.azure.com/org/project/_git/repo?path=/&version=GCsha",
+		},
+		{
+			title: "azure branch",
+			host:  "dev.azure.com",
+			loc: &urlx.Location{
+				Host:    "dev.azure.com",
+				Repo:    "org/project/_git/repo",
+				Ref:     "feature/x",
+				RefType: urlx.RefBranch,
+				Path:    "file.go",
+			},
+			want: "https://dev.azure.com/org/project/_git/repo?path=/file.go&version=GBfeature/x",
+		},
+		{
+			title: "azure visualstudio",
+			host:  "org.visualstudio.com",
+			loc: &urlx.Location{
+				Host: "org.visualstudio.com",
+				Repo: "DefaultCollection/my project/_git/repo",
+				Ref:  "sha",
+				Path: "file.go",
+			},
+			want: "https://dev.azure.com/org/my%20project/_git/repo?path=/file.go&version=GCsha",
+		},
+		{
+			title: "gitea commit line",
+			host:  "codeberg.org",
+			loc: &urlx.Location{
+				RepoURL: "https://codeberg.org/owner/repo",
+				Ref:     "sha",
+				RefType: urlx.RefCommit,
+				Path:    "dir/file.go",
+				Linum:   10,
+			},
+			want: "https://codeberg.org/owner/repo/src/commit/sha/dir/file.go#L10",
+		},
+		{
+			title: "gitea branch range",
+			host:  "gitea.example.com",
+			loc: &urlx.Location{
+				RepoURL:  "https://gitea.example.com/owner/repo",
+				Ref:      "main",
+				RefType:  urlx.RefBranch,
+				Path:     "dir/file.go",
+				Linum:    10,
+				EndLinum: 20,
+			},
+			want: "https://gitea.example.com/owner/repo/src/branch/main/dir/file.go#L10-L20",
+		},
+		{
+			title: "gerrit line",
+			host:  "gerrit.example.com",
+			loc: &urlx.Location{
+				BaseURL: "https://gerrit.example.com",
+				Repo:    "group/project",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+				Linum:   10,
+			},
+			want: "https://gerrit.example.com/plugins/gitiles/group/project/+/sha/dir/file.go#10",
+		},
+		{
+			title: "gerrit range",
+			host:  "gerrit.example.com",
+			loc: &urlx.Location{
+				BaseURL:  "https://gerrit.example.com",
+				Repo:     "group/project",
+				Ref:      "sha",
+				Path:     "dir/file.go",
+				Linum:    10,
+				EndLinum: 25,
+			},
+			want: "https://gerrit.example.com/plugins/gitiles/group/project/+/sha/dir/file.go#10",
+		},
+		{
+			title: "gerrit authenticated http",
+			host:  "gerrit.example.com",
+			loc: &urlx.Location{
+				BaseURL: "https://gerrit.example.com",
+				Repo:    "a/project",
+				Ref:     "sha",
+				Path:    "dir",
+				IsDir:   true,
+			},
+			want: "https://gerrit.example.com/plugins/gitiles/project/+/sha/dir/",
+		},
+		{
+			title: "googlesource root",
+			host:  "go.googlesource.com",
+			loc: &urlx.Location{
+				BaseURL: "https://go.googlesource.com",
+				Repo:    "go",
+				Ref:     "sha",
+				IsDir:   true,
+			},
+			want: "https://go.googlesource.com/go/+/sha/",
+		},
+		{
+			title: "sourcehut line",
+			host:  "git.sr.ht",
+			loc: &urlx.Location{
+				BaseURL: "https://git.sr.ht",
+				Repo:    "~user/repo",
+				Ref:     "sha",
+				Path:    "dir/file.go",
+				Linum:   10,
+			},
+			want: "https://git.sr.ht/~user/repo/tree/sha/item/dir/file.go#L10",
+		},
+		{
+			title: "sourcehut range",
+			host:  "git.sr.ht",
+			loc: &urlx.Location{
+				BaseURL:  "https://git.sr.ht",
+				Repo:     "~user/repo",
+				Ref:      "sha",
+				Path:     "dir/file.go",
+				Linum:    10,
+				EndLinum: 25,
+			},
+			want: "https://git.sr.ht/~user/repo/tree/sha/item/dir/file.go#L10-25",
+		},
+		{
+			title: "sourcehut root without tilde",
+			host:  "git.sr.ht",
+			loc: &urlx.Location{
+				BaseURL: "https://git.sr.ht",
+				Repo:    "user/repo",
+				Ref:     "sha",
+				IsDir:   true,
+			},
+			want: "https://git.sr.ht/~user/repo/tree/sha",
+		},
+		{
+			title: "cgit line",
+			host:  "git.kernel.org",
+			loc: &urlx.Location{
+				RepoURL: "https://git.kernel.org/pub/scm/git/git",
+				Ref:     "sha",
+				Path:    "dir/file.c",
+				Linum:   10,
+			},
+			want: "https://git.kernel.org/pub/scm/git/git/tree/dir/file.c?id=sha#n10",
+		},
+		{
+			title: "cgit range",
+			host:  "git.kernel.org",
+			loc: &urlx.Location{
+				RepoURL:  "https://git.kernel.org/pub/scm/git/git",
+				Ref:      "sha",
+				Path:     "dir/file.c",
+				Linum:    10,
+				EndLinum: 25,
+			},
+			want: "https://git.kernel.org/pub/scm/git/git/tree/dir/file.c?id=sha#n10",
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			got, err := urlx.DetectForge(tc.host).URL(tc.loc)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
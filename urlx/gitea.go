@@ -0,0 +1,29 @@
+package urlx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// giteaForge generates urls of Gitea, Forgejo and Codeberg
+// like https://codeberg.org/owner/repo/src/commit/ref/path#L10-L20.
+type giteaForge struct{}
+
+func (giteaForge) Name() string { return "gitea" }
+
+func (giteaForge) URL(loc *Location) (string, error) {
+	var fragment string
+	if loc.HasLine() {
+		fragment = fmt.Sprintf("#L%d", loc.Linum)
+		if loc.HasRange() {
+			fragment += fmt.Sprintf("-L%d", loc.EndLinum)
+		}
+	}
+	return fmt.Sprintf("%s/src/%s/%s/%s%s",
+		loc.RepoURL, loc.RefType, loc.Ref, loc.Path, fragment,
+	), nil
+}
+
+func isGiteaHost(host string) bool {
+	return host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo")
+}
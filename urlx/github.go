@@ -0,0 +1,21 @@
+package urlx
+
+import "fmt"
+
+// githubForge generates urls like https://github.com/owner/repo/blob/ref/path#L10.
+type githubForge struct{}
+
+func (githubForge) Name() string { return "github" }
+
+func (githubForge) URL(loc *Location) (string, error) {
+	var fragment string
+	if loc.HasLine() {
+		fragment = fmt.Sprintf("#L%d", loc.Linum)
+		if loc.HasRange() {
+			fragment += fmt.Sprintf("-L%d", loc.EndLinum)
+		}
+	}
+	return fmt.Sprintf("%s/blob/%s/%s%s",
+		loc.RepoURL, loc.Ref, loc.Path, fragment,
+	), nil
+}
@@ -0,0 +1,62 @@
+package urlx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// gitilesForge generates urls of Gitiles like https://host/plugins/gitiles/project/+/ref/path#10.
+type gitilesForge struct {
+	// plugin is true if gitiles runs as the plugin of Gerrit under /plugins/gitiles.
+	plugin bool
+	// root is the url of the gitiles web root like https://host/gitiles.
+	// Overrides the default root.
+	root string
+}
+
+func (f gitilesForge) Name() string {
+	if f.plugin {
+		return "gerrit"
+	}
+	return "gitiles"
+}
+
+func (f gitilesForge) URL(loc *Location) (string, error) {
+	var fragment string
+	// gitiles has no anchor of the range of lines
+	if loc.HasLine() {
+		fragment = fmt.Sprintf("#%d", loc.Linum)
+	}
+	path := loc.Path
+	if path != "" && loc.IsDir {
+		path += "/"
+	}
+	return fmt.Sprintf("%s/%s/+/%s/%s%s",
+		f.rootURL(loc), gitilesProject(loc.Repo), loc.Ref, path, fragment,
+	), nil
+}
+
+func (f gitilesForge) rootURL(loc *Location) string {
+	switch {
+	case f.root != "":
+		return strings.TrimSuffix(f.root, "/")
+	case f.plugin:
+		return loc.BaseURL + "/plugins/gitiles"
+	default:
+		return loc.BaseURL
+	}
+}
+
+// withSettings sets the web root by gitilesRoot setting.
+func (f gitilesForge) withSettings(host string, settings hostSettings) Forge {
+	if root, ok := settings.get(host, "gitilesRoot"); ok {
+		f.root = root
+	}
+	return &f
+}
+
+// gitilesProject returns the project name of the repository.
+// Removes the prefix a/ of the authenticated http remote like https://host/a/project.
+func gitilesProject(repo string) string {
+	return strings.TrimPrefix(repo, "a/")
+}
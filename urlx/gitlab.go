@@ -0,0 +1,25 @@
+package urlx
+
+import "fmt"
+
+// gitlabForge generates urls like https://gitlab.com/group/subgroup/repo/-/blob/ref/path#L10.
+type gitlabForge struct{}
+
+func (gitlabForge) Name() string { return "gitlab" }
+
+func (gitlabForge) URL(loc *Location) (string, error) {
+	kind := "blob"
+	if loc.IsDir {
+		kind = "tree"
+	}
+	var fragment string
+	if loc.HasLine() {
+		fragment = fmt.Sprintf("#L%d", loc.Linum)
+		if loc.HasRange() {
+			fragment += fmt.Sprintf("-%d", loc.EndLinum)
+		}
+	}
+	return fmt.Sprintf("%s/-/%s/%s/%s%s",
+		loc.RepoURL, kind, loc.Ref, loc.Path, fragment,
+	), nil
+}
@@ -0,0 +1,45 @@
+package urlx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/berquerant/gbrowse/ctxlog"
+)
+
+// mapHost replaces the web url of the location by the host settings of the remote host:
+//
+//	baseURL    url of the web ui of the host, e.g. https://git.corp.example.
+//	           $1 is expanded by the submatch of the regular expression pattern.
+//	pathPrefix path of the web ui under baseURL, e.g. ghe.
+func mapHost(ctx context.Context, loc *Location, host string, settings hostSettings) error {
+	baseURL, hasBaseURL := settings.expand(host, "baseURL")
+	prefix, hasPrefix := settings.get(host, "pathPrefix")
+	if !hasBaseURL && !hasPrefix {
+		return nil
+	}
+
+	if hasBaseURL {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid baseURL for %s: %w", host, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid baseURL for %s: %s has no scheme or host", host, baseURL)
+		}
+		loc.BaseURL = strings.TrimSuffix(baseURL, "/")
+		loc.Host = u.Hostname()
+	}
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		loc.BaseURL += "/" + prefix
+	}
+	loc.RepoURL = loc.BaseURL + "/" + loc.Repo
+
+	ctxlog.From(ctx).Debug("map host",
+		ctxlog.S("host", host),
+		ctxlog.S("base", loc.BaseURL),
+	)
+	return nil
+}
@@ -0,0 +1,80 @@
+package urlx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/berquerant/gbrowse/ctxlog"
+)
+
+// ErrNoMatch means that no lines match the pattern.
+var ErrNoMatch = errors.New("no lines match")
+
+// findLines returns the first line of the file at the ref matching the pattern,
+// and the first line from it matching endPattern if not empty.
+func (r *Repo) findLines(ctx context.Context, loc *Location, pattern, endPattern string) (int, int, error) {
+	if loc.IsDir {
+		return 0, 0, fmt.Errorf("pattern for directory %s", loc.Path)
+	}
+	content, err := r.show(ctx, loc)
+	if err != nil {
+		return 0, 0, err
+	}
+	lines := strings.Split(content, "\n")
+
+	starts, err := matchLines(lines, pattern, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(starts) == 0 {
+		return 0, 0, fmt.Errorf("%w /%s/ in %s at %s", ErrNoMatch, pattern, loc.Path, loc.Ref)
+	}
+	if len(starts) > 1 {
+		ctxlog.From(ctx).Warn("several lines match, use the first",
+			ctxlog.S("pattern", pattern),
+			ctxlog.Any("candidates", starts),
+		)
+	}
+	start := starts[0]
+	if endPattern == "" {
+		return start, 0, nil
+	}
+
+	ends, err := matchLines(lines, endPattern, start-1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(ends) == 0 {
+		return 0, 0, fmt.Errorf("%w /%s/ after line %d in %s at %s", ErrNoMatch, endPattern, start, loc.Path, loc.Ref)
+	}
+	return start, ends[0], nil
+}
+
+// show returns the content of the file at the ref.
+// Reads the branch of the remote because the web ui shows it.
+func (r *Repo) show(ctx context.Context, loc *Location) (string, error) {
+	if loc.RefType == RefBranch {
+		if content, err := r.gitCommand.Show(ctx, r.remote+"/"+loc.Ref, loc.Path); err == nil {
+			return content, nil
+		}
+	}
+	return r.gitCommand.Show(ctx, loc.Ref, loc.Path)
+}
+
+// matchLines returns the line numbers of the lines from the offset matching the pattern.
+func matchLines(lines []string, pattern string, offset int) ([]int, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern /%s/: %w", pattern, err)
+	}
+	var linums []int
+	for i := offset; i < len(lines); i++ {
+		if re.MatchString(lines[i]) {
+			linums = append(linums, i+1)
+		}
+	}
+	return linums, nil
+}
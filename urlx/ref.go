@@ -0,0 +1,129 @@
+package urlx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/berquerant/gbrowse/ctxlog"
+	"github.com/berquerant/gbrowse/git"
+)
+
+// Ref modes.
+const (
+	// RefModeCommit is the commit hash of HEAD.
+	RefModeCommit = "commit"
+	// RefModeBranch is the current branch.
+	RefModeBranch = "branch"
+	// RefModeDefault is the default branch of the remote.
+	RefModeDefault = "default"
+	// RefModeTag is the latest tag reachable from HEAD.
+	RefModeTag = "tag"
+)
+
+// resolveRef returns the ref to be opened by the mode.
+// The mode other than the ref modes is a ref expression like v1.2.0, main or HEAD~3.
+func resolveRef(ctx context.Context, gitCommand git.Git, remote, mode string) (string, RefType, error) {
+	switch mode {
+	case RefModeCommit:
+		ref, err := gitCommand.CommitHash(ctx)
+		return ref, RefCommit, err
+	case RefModeBranch:
+		ref, err := gitCommand.ShowCurrent(ctx)
+		if err == nil && ref == "" {
+			err = fmt.Errorf("no current branch, HEAD is detached")
+		}
+		return ref, RefBranch, err
+	case RefModeDefault:
+		ref, err := gitCommand.DefaultBranch(ctx, remote)
+		return ref, RefBranch, err
+	case RefModeTag:
+		ref, err := gitCommand.DescribeTag(ctx)
+		return ref, RefTag, err
+	default:
+		return resolveRefExpr(ctx, gitCommand, mode)
+	}
+}
+
+// resolveRefExpr returns the branch or the tag if the expression is a ref,
+// otherwise the commit hash.
+func resolveRefExpr(ctx context.Context, gitCommand git.Git, expr string) (string, RefType, error) {
+	name, err := gitCommand.SymbolicFullName(ctx, expr)
+	if err != nil {
+		return "", "", err
+	}
+	if x, ok := strings.CutPrefix(name, "refs/heads/"); ok {
+		return x, RefBranch, nil
+	}
+	if x, ok := strings.CutPrefix(name, "refs/tags/"); ok {
+		return x, RefTag, nil
+	}
+	if x, ok := strings.CutPrefix(name, "refs/remotes/"); ok {
+		// remove the remote name
+		if _, branch, ok := strings.Cut(x, "/"); ok {
+			return branch, RefBranch, nil
+		}
+	}
+	ref, err := gitCommand.RevParseCommit(ctx, expr)
+	return ref, RefCommit, err
+}
+
+// Unpushed modes, the behavior when the commit is not pushed.
+const (
+	// UnpushedWarn warns and opens the commit.
+	UnpushedWarn = "warn"
+	// UnpushedFallback opens the nearest pushed ancestor.
+	UnpushedFallback = "fallback"
+	// UnpushedError fails.
+	UnpushedError = "error"
+)
+
+// ErrUnpushed means that the commit is not reachable from any remote-tracking refs.
+var ErrUnpushed = errors.New("commit is not pushed")
+
+// checkPushed checks if the commit of the ref is pushed and handles it by the unpushed mode.
+func (r *Repo) checkPushed(ctx context.Context, mode string) error {
+	var (
+		logger = ctxlog.From(ctx)
+		commit = r.base.Ref
+	)
+	switch mode {
+	case UnpushedWarn, UnpushedFallback, UnpushedError:
+	default:
+		return fmt.Errorf("unknown unpushed mode %s", mode)
+	}
+
+	pushed, err := r.gitCommand.IsPushed(ctx, r.remote, commit)
+	if err != nil {
+		return err
+	}
+	if pushed {
+		return nil
+	}
+
+	switch mode {
+	case UnpushedWarn:
+		logger.Warn("commit is not pushed, the url may not be found",
+			ctxlog.S("commit", commit),
+		)
+		return nil
+	case UnpushedFallback:
+		ancestor, err := r.gitCommand.PushedAncestor(ctx, r.remote, commit)
+		if err != nil {
+			return err
+		}
+		if ancestor == "" {
+			return fmt.Errorf("%w: %s, no pushed ancestors", ErrUnpushed, commit)
+		}
+		logger.Debug("substitute unpushed commit",
+			ctxlog.S("commit", commit),
+			ctxlog.S("ancestor", ancestor),
+		)
+		r.base.Ref = ancestor
+		r.unpushed = commit
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnpushed, commit)
+	}
+}
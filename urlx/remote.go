@@ -0,0 +1,68 @@
+package urlx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/berquerant/gbrowse/ctxlog"
+	"github.com/berquerant/gbrowse/git"
+	"github.com/berquerant/gbrowse/parse"
+	"github.com/berquerant/gbrowse/sshconfig"
+)
+
+// ErrNoRemote means that the repository has no remotes.
+var ErrNoRemote = errors.New("no remotes")
+
+// resolveRemote selects the remote.
+// The remote is the specified one if not empty,
+// the remote of the upstream of the current branch,
+// or the first remote, in that order.
+func resolveRemote(ctx context.Context, gitCommand git.Git, remote string) (string, error) {
+	if remote != "" {
+		return remote, nil
+	}
+
+	branch, err := gitCommand.ShowCurrent(ctx)
+	if err != nil {
+		return "", err
+	}
+	if branch != "" {
+		r, err := gitCommand.BranchRemote(ctx, branch)
+		if err != nil {
+			return "", err
+		}
+		// . means the upstream is the local branch
+		if r != "" && r != "." {
+			return r, nil
+		}
+	}
+
+	remotes, err := gitCommand.Remotes(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(remotes) == 0 {
+		return "", ErrNoRemote
+	}
+	return remotes[0], nil
+}
+
+// resolveSSHHost replaces the host alias of the ssh remote with the HostName of the ssh config file.
+// Does nothing if the file is empty.
+func resolveSSHHost(ctx context.Context, remoteURL *parse.RemoteURL, sshConfigFile string) error {
+	if remoteURL.Scheme != "ssh" || sshConfigFile == "" {
+		return nil
+	}
+	c, err := sshconfig.Load(sshConfigFile)
+	if err != nil {
+		return err
+	}
+	if host := c.HostName(remoteURL.Host); host != remoteURL.Host {
+		ctxlog.From(ctx).Debug("resolve ssh host",
+			ctxlog.S("alias", remoteURL.Host),
+			ctxlog.S("host", host),
+		)
+		remoteURL.Host = host
+	}
+	return nil
+}
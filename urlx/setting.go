@@ -0,0 +1,141 @@
+package urlx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/berquerant/gbrowse/git"
+)
+
+const (
+	settingPrefix  = "gbrowse."
+	settingPattern = `^gbrowse\.`
+)
+
+// hostSettings is the configuration per host from git config like:
+//
+//	[gbrowse "git.example.com"]
+//		forge = gitea
+//
+// The subsection is a host name, a glob pattern of host names
+// or a regular expression enclosed in slashes like /^ssh\.(.+)$/.
+type hostSettings []git.ConfigEntry
+
+// readHostSettings reads the settings from the config file if not empty, and git config.
+// The file is written in the git config format and overridden by git config.
+func readHostSettings(ctx context.Context, gitCommand git.Git, configFile string) (hostSettings, error) {
+	var settings hostSettings
+	if configFile != "" {
+		if _, err := os.Stat(configFile); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		entries, err := gitCommand.ConfigFileGetRegexp(ctx, configFile, settingPattern)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, entries...)
+	}
+	entries, err := gitCommand.ConfigGetRegexp(ctx, settingPattern)
+	if err != nil {
+		return nil, err
+	}
+	settings = append(settings, entries...)
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (s hostSettings) validate() error {
+	for _, e := range s {
+		pattern, _, ok := splitSettingKey(e.Key())
+		if !ok {
+			continue
+		}
+		if expr, ok := regexpPattern(pattern); ok {
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("invalid host pattern %s: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// get returns the value of the name for the host.
+// The last matched entry wins, like git config.
+func (s hostSettings) get(host, name string) (string, bool) {
+	value, _, found := s.lookup(host, name)
+	return value, found
+}
+
+// expand returns the value of the name for the host
+// with the submatches of the regular expression pattern like $1 expanded.
+func (s hostSettings) expand(host, name string) (string, bool) {
+	value, pattern, found := s.lookup(host, name)
+	if !found {
+		return "", false
+	}
+	expr, ok := regexpPattern(pattern)
+	if !ok {
+		return value, true
+	}
+	re := regexp.MustCompile(expr)
+	host = strings.ToLower(host)
+	return string(re.ExpandString(nil, value, host, re.FindStringSubmatchIndex(host))), true
+}
+
+func (s hostSettings) lookup(host, name string) (string, string, bool) {
+	var (
+		value   string
+		matched string
+		found   bool
+	)
+	for _, e := range s {
+		pattern, key, ok := splitSettingKey(e.Key())
+		if !ok || key != strings.ToLower(name) {
+			continue
+		}
+		if matchHost(pattern, host) {
+			value = e.Value()
+			matched = pattern
+			found = true
+		}
+	}
+	return value, matched, found
+}
+
+// matchHost reports whether the host matches the glob or the regular expression pattern.
+func matchHost(pattern, host string) bool {
+	host = strings.ToLower(host)
+	if expr, ok := regexpPattern(pattern); ok {
+		re, err := regexp.Compile(expr)
+		return err == nil && re.MatchString(host)
+	}
+	matched, _ := path.Match(strings.ToLower(pattern), host)
+	return matched
+}
+
+// regexpPattern returns the regular expression of the pattern enclosed in slashes.
+func regexpPattern(pattern string) (string, bool) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		return pattern[1 : len(pattern)-1], true
+	}
+	return "", false
+}
+
+// splitSettingKey splits gbrowse.PATTERN.KEY into PATTERN and KEY.
+func splitSettingKey(key string) (string, string, bool) {
+	x, ok := strings.CutPrefix(key, settingPrefix)
+	if !ok {
+		return "", "", false
+	}
+	i := strings.LastIndex(x, ".")
+	if i < 0 {
+		return "", "", false
+	}
+	return x[:i], x[i+1:], true
+}
@@ -0,0 +1,36 @@
+package urlx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sourcehutForge generates urls of SourceHut like https://git.sr.ht/~user/repo/tree/ref/item/path#L10.
+type sourcehutForge struct{}
+
+func (sourcehutForge) Name() string { return "sourcehut" }
+
+func (sourcehutForge) URL(loc *Location) (string, error) {
+	var path string
+	if loc.Path != "" {
+		path = "/item/" + loc.Path
+	}
+	var fragment string
+	if loc.HasLine() {
+		fragment = fmt.Sprintf("#L%d", loc.Linum)
+		if loc.HasRange() {
+			fragment += fmt.Sprintf("-%d", loc.EndLinum)
+		}
+	}
+	return fmt.Sprintf("%s/%s/tree/%s%s%s",
+		loc.BaseURL, sourcehutRepo(loc.Repo), loc.Ref, path, fragment,
+	), nil
+}
+
+// sourcehutRepo returns the repository path with the owner ~user.
+func sourcehutRepo(repo string) string {
+	if strings.HasPrefix(repo, "~") {
+		return repo
+	}
+	return "~" + repo
+}
@@ -0,0 +1,86 @@
+package urlx
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// templateForge generates urls by the templates of the host settings,
+// falls back to the base forge if no template for the location.
+//
+// The templates are executed with Location, e.g.
+//
+//	{{.RepoURL}}/view/{{.Ref}}/{{.Path}}#line-{{.Linum}}
+type templateForge struct {
+	base      Forge
+	file      *template.Template
+	dir       *template.Template
+	line      *template.Template
+	lineRange *template.Template
+}
+
+func (templateForge) Name() string { return "template" }
+
+func (f templateForge) URL(loc *Location) (string, error) {
+	tmpl := f.file
+	switch {
+	case loc.IsDir:
+		tmpl = f.dir
+	case loc.HasRange() && f.lineRange != nil:
+		tmpl = f.lineRange
+	case loc.HasLine():
+		tmpl = f.line
+	}
+	if tmpl == nil {
+		return f.base.URL(loc)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, loc); err != nil {
+		return "", fmt.Errorf("failed to execute template %s: %w", tmpl.Name(), err)
+	}
+	return b.String(), nil
+}
+
+var templateFuncMap = template.FuncMap{
+	"pathEscape":  url.PathEscape,
+	"queryEscape": url.QueryEscape,
+	"lower":       strings.ToLower,
+	"upper":       strings.ToUpper,
+	"trimPrefix":  strings.TrimPrefix,
+	"trimSuffix":  strings.TrimSuffix,
+}
+
+// newTemplateForge returns the template forge if the host has any template settings.
+//
+// The settings are fileTemplate, dirTemplate, lineTemplate and rangeTemplate.
+func newTemplateForge(base Forge, host string, settings hostSettings) (Forge, bool, error) {
+	var (
+		f     = &templateForge{base: base}
+		found bool
+	)
+	for _, t := range []struct {
+		name string
+		dest **template.Template
+	}{
+		{name: "fileTemplate", dest: &f.file},
+		{name: "dirTemplate", dest: &f.dir},
+		{name: "lineTemplate", dest: &f.line},
+		{name: "rangeTemplate", dest: &f.lineRange},
+	} {
+		value, ok := settings.get(host, t.name)
+		if !ok {
+			continue
+		}
+		tmpl, err := template.New(t.name).Funcs(templateFuncMap).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid %s for %s: %w", t.name, host, err)
+		}
+		*t.dest = tmpl
+		found = true
+	}
+	return f, found, nil
+}
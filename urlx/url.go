@@ -5,59 +5,187 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/berquerant/gbrowse/ctxlog"
 	"github.com/berquerant/gbrowse/git"
 	"github.com/berquerant/gbrowse/parse"
 )
 
+//go:generate go tool goconfig -field "ConfigFile string|Ref string|Unpushed string|Remote string|Push bool|SSHConfig string" -option -output config_generated.go
+
 // Build assembles url from repository and specified path.
-func Build(ctx context.Context, gitCommand git.Git, target *parse.Target) (string, error) {
-	u, err := build(ctx, gitCommand, target)
+func Build(ctx context.Context, gitCommand git.Git, target *parse.Target, opt ...ConfigOption) (string, error) {
+	repo, err := NewRepo(ctx, gitCommand, opt...)
+	if err != nil {
+		return "", err
+	}
+	return repo.Build(ctx, target)
+}
+
+// Repo is the remote repository and the ref shared by the urls.
+type Repo struct {
+	gitCommand git.Git
+	remote     string
+	forge      Forge
+	// base is the location of the root of the repository.
+	base *Location
+	// unpushed is the unpushed commit replaced by base.Ref.
+	unpushed string
+}
+
+// NewRepo resolves the remote repository and the ref.
+func NewRepo(ctx context.Context, gitCommand git.Git, opt ...ConfigOption) (*Repo, error) {
+	config := NewConfigBuilder().
+		ConfigFile("").
+		Ref(RefModeCommit).
+		Unpushed(UnpushedWarn).
+		Remote("").
+		Push(false).
+		SSHConfig("").
+		Build()
+	config.Apply(opt...)
+	r, err := newRepo(ctx, gitCommand, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository: %w", err)
+	}
+	return r, nil
+}
+
+func newRepo(ctx context.Context, gitCommand git.Git, config *Config) (*Repo, error) {
+	remote, err := resolveRemote(ctx, gitCommand, config.Remote.Get())
+	if err != nil {
+		return nil, err
+	}
+	repoURL, err := gitCommand.RemoteURL(ctx, remote, config.Push.Get())
+	if err != nil {
+		return nil, err
+	}
+	remoteURL, err := parse.ReadRemoteURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveSSHHost(ctx, remoteURL, config.SSHConfig.Get()); err != nil {
+		return nil, err
+	}
+	base, err := newLocation(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := readHostSettings(ctx, gitCommand, config.ConfigFile.Get())
+	if err != nil {
+		return nil, err
+	}
+	host := base.Host
+	if err := mapHost(ctx, base, host, settings); err != nil {
+		return nil, err
+	}
+	forge, err := selectForge(host, base.Host, settings)
+	if err != nil {
+		return nil, err
+	}
+	if base.Ref, base.RefType, err = resolveRef(ctx, gitCommand, remote, config.Ref.Get()); err != nil {
+		return nil, err
+	}
+
+	r := &Repo{
+		gitCommand: gitCommand,
+		remote:     remote,
+		forge:      forge,
+		base:       base,
+	}
+	if base.RefType == RefCommit {
+		if err := r.checkPushed(ctx, config.Unpushed.Get()); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Remote returns the name of the remote.
+func (r *Repo) Remote() string {
+	return r.remote
+}
+
+// Unpushed returns the unpushed commit replaced by the pushed ancestor.
+func (r *Repo) Unpushed() (string, bool) {
+	return r.unpushed, r.unpushed != ""
+}
+
+// Build assembles url of the target.
+func (r *Repo) Build(ctx context.Context, target *parse.Target) (string, error) {
+	u, err := r.build(ctx, target)
 	if err != nil {
 		return "", fmt.Errorf("failed to build url: %w", err)
 	}
 	return u, nil
 }
 
-func build(ctx context.Context, gitCommand git.Git, target *parse.Target) (string, error) {
-	var (
-		repoURL  string
-		ref      string
-		path     string
-		fragment string
+func (r *Repo) build(ctx context.Context, target *parse.Target) (string, error) {
+	loc := *r.base
+	if isDir, err := isDirectory(target.Path()); err != nil || isDir {
+		if loc.Path, err = r.dirPath(ctx, target.Path()); err != nil {
+			return "", err
+		}
+		loc.IsDir = isDir
+	} else if loc.Path, err = r.gitCommand.RelativePath(ctx, target.Path()); err != nil {
+		return "", err
+	}
+	if linum, ok := target.Linum(); ok {
+		loc.Linum = linum
+	}
+	if endLinum, ok := target.EndLinum(); ok {
+		loc.EndLinum = endLinum
+	}
+	if column, ok := target.Column(); ok {
+		loc.Column = column
+	}
+	if pattern, endPattern, ok := target.Pattern(); ok {
+		linum, endLinum, err := r.findLines(ctx, &loc, pattern, endPattern)
+		if err != nil {
+			return "", err
+		}
+		loc.Linum, loc.EndLinum = linum, endLinum
+	}
+
+	ctxlog.From(ctx).Debug("build url",
+		ctxlog.S("forge", r.forge.Name()),
+		ctxlog.Any("location", loc),
 	)
-	if err := func() error {
-		var err error
-		if repoURL, err = gitCommand.RemoteOriginURL(ctx); err != nil {
-			return err
+	return r.forge.URL(&loc)
+}
+
+// dirPath returns the path from the root of the repository of the directory.
+func (r *Repo) dirPath(ctx context.Context, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		top, err := r.gitCommand.TopLevel(ctx)
+		if err != nil {
+			return "", err
 		}
-		repoURL = parse.ReadRepoURL(repoURL)
-		if ref, err = gitCommand.CommitHash(ctx); err != nil {
-			return err
+		rel, err := filepath.Rel(top, path)
+		if err != nil {
+			return "", err
 		}
-		if isDir, err := isDirectory(target.Path()); err != nil || isDir {
-			r, err := gitCommand.ShowPrefix(ctx)
-			if err != nil {
-				return err
-			}
-			path = filepath.Join(r, target.Path())
-		} else if path, err = gitCommand.RelativePath(ctx, target.Path()); err != nil {
-			return err
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("%s is outside of the repository %s", path, top)
 		}
-		if linum, ok := target.Linum(); ok {
-			fragment = fmt.Sprintf("#L%d", linum)
+		if rel == "." {
+			return "", nil
 		}
-		return nil
-	}(); err != nil {
-		return "", err
+		return filepath.ToSlash(rel), nil
 	}
 
-	return fmt.Sprintf("%s/blob/%s/%s%s",
-		repoURL, ref, path, fragment,
-	), nil
+	prefix, err := r.gitCommand.ShowPrefix(ctx)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(prefix, path), nil
 }
 
 func isDirectory(path string) (bool, error) {
+	if path == "" {
+		path = "."
+	}
 	x, err := os.Stat(path)
 	if err != nil {
 		return false, err